@@ -0,0 +1,356 @@
+package iter
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNacked     = errors.New("message nacked")
+	ErrAckTimeout = errors.New("message ack timeout")
+	ErrAckClosed  = errors.New("ack source closed")
+)
+
+// DefaultMaxAttempts limit deliveries of a message when AckPolicy.MaxAttempts is 0
+const DefaultMaxAttempts = 10
+
+// AckPolicy describe how AckSource() redelivers messages
+type AckPolicy struct {
+	Timeout     time.Duration // redeliver message not acked in Timeout, 0 means no timeout
+	MaxAttempts int           // deliveries including the first one, 0 means DefaultMaxAttempts and negative means no limit
+}
+
+// Message is an element of AckSource() which must be acked when it is processed
+// nacked or timed-out message is delivered again with the next Attempt
+type Message[T any] struct {
+	Value   T
+	Attempt int // delivery attempt from 1
+
+	settler settler
+}
+
+// settler settle a delivery once, ok means ack
+type settler interface{ settle(ok bool) }
+
+// Ack mark the message processed, only the first Ack() or Nack() of a delivery counts
+func (m Message[T]) Ack() {
+	if m.settler != nil {
+		m.settler.settle(true)
+	}
+}
+
+// Nack ask the message to be delivered again
+func (m Message[T]) Nack() {
+	if m.settler != nil {
+		m.settler.settle(false)
+	}
+}
+
+type ackItem[T any] struct {
+	v        T
+	attempts int
+	cur      *delivery[T] // the delivery in flight
+	deadline time.Time
+	index    int   // index in inflight heap, -1 if not in flight
+	err      error // why it waits for redelivery
+	done     bool
+}
+
+type delivery[T any] struct {
+	src     *ackSource[T]
+	item    *ackItem[T]
+	settled bool
+}
+
+// inflight is a heap of delivered messages ordered by deadline
+type inflight[T any] []*ackItem[T]
+
+func (h inflight[T]) Len() int           { return len(h) }
+func (h inflight[T]) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+func (h inflight[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *inflight[T]) Push(x any) {
+	item := x.(*ackItem[T])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+func (h *inflight[T]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	item.index = -1
+	*h = old[:len(old)-1]
+	return item
+}
+
+type ackSource[T any] struct {
+	mu         sync.Mutex
+	ch         <-chan T
+	clock      Clock
+	policy     AckPolicy
+	deadLetter func(Failure[T])
+
+	closed    bool // ch is closed
+	stopped   bool // Close() is called
+	inflight  inflight[T]
+	redeliver []*ackItem[T]
+	dead      []Failure[T] // failures to report out of the lock
+	wake      chan struct{}
+}
+
+// AckSource iterate ch as messages and redeliver nacked or timed-out ones before new elements
+// it ends when ch is closed and every delivered message is acked or dead, so Next() blocks while the consumer holds unsettled messages
+// consumers holding several messages at once, like Chunk(), need a Timeout to get the held ones back
+// deadLetter receives messages out of MaxAttempts, WithClock() set time source of timeouts
+// Close() releases a blocked Next() and passes unsettled messages to deadLetter on the calling goroutine
+func AckSource[T any](ch <-chan T, policy AckPolicy, deadLetter func(Failure[T]), opts ...Option) Iterator[Message[T]] {
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+
+	s := &ackSource[T]{
+		ch:         ch,
+		clock:      newOptions(opts...).clock,
+		policy:     policy,
+		deadLetter: deadLetter,
+		wake:       make(chan struct{}, 1),
+	}
+
+	return &withNext[Message[T]]{
+		name:  "ackSource",
+		next:  s.next,
+		close: s.close,
+	}
+}
+
+// close stop the source and pass messages waiting for redelivery or in flight to deadLetter
+// so that they are not lost, settling them afterward has no effect
+func (s *ackSource[T]) close() {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		for _, item := range s.redeliver {
+			if !item.done {
+				s.kill(item, item.err)
+			}
+		}
+		for _, item := range s.inflight {
+			item.index = -1
+			s.kill(item, ErrAckClosed)
+		}
+		s.redeliver, s.inflight = nil, nil
+	}
+	s.mu.Unlock()
+
+	s.reportDead()
+	s.signal()
+}
+
+func (s *ackSource[T]) kill(item *ackItem[T], err error) {
+	item.done = true
+	s.dead = append(s.dead, Failure[T]{Value: item.v, Err: err, Attempts: item.attempts})
+}
+
+func (s *ackSource[T]) next() (r Message[T], ok bool) {
+	for {
+		s.mu.Lock()
+		if s.stopped {
+			s.mu.Unlock()
+			return r, false
+		}
+
+		now := s.clock.Now()
+		s.expire(now)
+
+		var item *ackItem[T]
+		for len(s.redeliver) > 0 && item == nil {
+			if !s.redeliver[0].done {
+				item = s.redeliver[0]
+			}
+			s.redeliver = s.redeliver[1:]
+		}
+		if item != nil {
+			r = s.deliver(item, now)
+		}
+
+		finished := item == nil && s.closed && len(s.inflight) == 0
+		timeout := s.nextTimeout(now)
+		s.mu.Unlock()
+		s.reportDead()
+
+		switch {
+		case item != nil:
+			return r, true
+		case finished:
+			return r, false
+		}
+
+		var timer <-chan time.Time
+		if timeout > 0 {
+			timer = s.clock.After(timeout)
+		}
+		ch := s.ch
+		if s.closed {
+			ch = nil
+		}
+
+		select {
+		case v, ok := <-ch:
+			s.mu.Lock()
+			if !ok {
+				s.closed = true
+				s.mu.Unlock()
+				continue
+			}
+			r = s.deliver(&ackItem[T]{v: v, index: -1}, s.clock.Now())
+			s.mu.Unlock()
+			return r, true
+		case <-s.wake:
+		case <-timer:
+		}
+	}
+}
+
+func (s *ackSource[T]) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *ackSource[T]) deliver(item *ackItem[T], now time.Time) Message[T] {
+	item.attempts++
+	item.cur = &delivery[T]{src: s, item: item}
+	item.deadline = time.Time{}
+	if s.policy.Timeout > 0 {
+		item.deadline = now.Add(s.policy.Timeout)
+	}
+	heap.Push(&s.inflight, item)
+	return Message[T]{Value: item.v, Attempt: item.attempts, settler: item.cur}
+}
+
+// expire requeue messages whose deadline passed, in order of deadline
+func (s *ackSource[T]) expire(now time.Time) {
+	for len(s.inflight) > 0 {
+		item := s.inflight[0]
+		if item.deadline.IsZero() || now.Before(item.deadline) {
+			return
+		}
+
+		heap.Pop(&s.inflight)
+		item.cur = nil
+		s.requeue(item, ErrAckTimeout)
+	}
+}
+
+// nextTimeout return duration until the earliest deadline, 0 if there is none
+func (s *ackSource[T]) nextTimeout(now time.Time) time.Duration {
+	if len(s.inflight) == 0 || s.inflight[0].deadline.IsZero() {
+		return 0
+	}
+	return max(s.inflight[0].deadline.Sub(now), time.Nanosecond)
+}
+
+func (s *ackSource[T]) requeue(item *ackItem[T], err error) {
+	if s.policy.MaxAttempts > 0 && item.attempts >= s.policy.MaxAttempts {
+		s.kill(item, err)
+		return
+	}
+	item.err = err
+	s.redeliver = append(s.redeliver, item)
+}
+
+func (s *ackSource[T]) reportDead() {
+	s.mu.Lock()
+	dead := s.dead
+	s.dead = nil
+	s.mu.Unlock()
+
+	if s.deadLetter != nil {
+		for _, f := range dead {
+			s.deadLetter(f)
+		}
+	}
+}
+
+// settle ack or nack the delivery, late ack of a timed-out delivery still marks the message processed
+func (d *delivery[T]) settle(ok bool) {
+	s := d.src
+	s.mu.Lock()
+	defer func() {
+		s.mu.Unlock()
+		s.signal()
+	}()
+
+	item := d.item
+	if d.settled || item.done {
+		return
+	}
+	d.settled = true
+
+	if ok {
+		item.done = true
+		if item.index >= 0 {
+			heap.Remove(&s.inflight, item.index)
+		}
+		return
+	}
+
+	if item.cur == d {
+		heap.Remove(&s.inflight, item.index)
+		item.cur = nil
+		s.requeue(item, ErrNacked)
+	}
+}
+
+// MapMessage map values of messages keeping their acknowledgement
+// message whose mapper returns error or panics is nacked and dropped, the rest are emitted in order like FilterMap()
+// a message failing every time is redelivered until AckPolicy.MaxAttempts of its source
+func MapMessage[T1, T2 any](it Iterator[Message[T1]], mapper func(T1) (T2, error), opts ...Option) Iterator[Message[T2]] {
+	return describe(FilterMap(it, func(m Message[T1]) (r Message[T2], ok bool) {
+		v, err := callMapper(mapper, m.Value)
+		if err != nil {
+			m.Nack()
+			return r, false
+		}
+		return Message[T2]{Value: v, Attempt: m.Attempt, settler: m.settler}, true
+	}, opts...), "mapMessage", it)
+}
+
+func callMapper[T1, T2 any](mapper func(T1) (T2, error), v T1) (r T2, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return mapper(v)
+}
+
+// EachAck call fn for each message in order, ack the message when fn returns nil and nack it otherwise
+// panic of fn is recovered and nacks the message
+func EachAck[T any](it Iterator[Message[T]], fn func(T) error) {
+	for m, ok := it.Next(); ok; m, ok = it.Next() {
+		if _, err := callMapper(func(v T) (struct{}, error) { return struct{}{}, fn(v) }, m.Value); err != nil {
+			m.Nack()
+			continue
+		}
+		m.Ack()
+	}
+}
+
+// SinkAck is Sink() acking each message after fn returns nil, the failed message is nacked and stops the sink
+func SinkAck[T any](it Iterator[Message[T]], fn func(T) error) error {
+	return Sink(it, func(m Message[T]) error {
+		if err := fn(m.Value); err != nil {
+			m.Nack()
+			return err
+		}
+		m.Ack()
+		return nil
+	})
+}
@@ -0,0 +1,277 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memBroker is an in-memory stand-in of a message broker
+type memBroker[T any] struct{ ch chan T }
+
+func newMemBroker[T any](s ...T) *memBroker[T] {
+	b := &memBroker[T]{ch: make(chan T, len(s))}
+	for _, v := range s {
+		b.Publish(v)
+	}
+	return b
+}
+
+func (b *memBroker[T]) Publish(v T)        { b.ch <- v }
+func (b *memBroker[T]) Close()             { close(b.ch) }
+func (b *memBroker[T]) Messages() <-chan T { return b.ch }
+
+func TestAckSource(t *testing.T) {
+	b := newMemBroker(1, 2, 3)
+	b.Close()
+
+	var got []int
+	EachAck(AckSource(b.Messages(), AckPolicy{}, nil), func(v int) error {
+		got = append(got, v)
+		return nil
+	})
+	require.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestAckSourceNack(t *testing.T) {
+	b := newMemBroker(1, 2, 3, 4)
+	b.Close()
+
+	attempts := map[int]int{}
+	var done []int
+	EachAck(AckSource(b.Messages(), AckPolicy{}, nil), func(v int) error {
+		attempts[v]++
+		if v%2 == 0 && attempts[v] == 1 {
+			return errors.New("fail")
+		}
+		if v == 3 && attempts[v] == 1 {
+			panic("crash")
+		}
+		done = append(done, v)
+		return nil
+	})
+	require.Equal(t, []int{1, 2, 3, 4}, done) // redelivery comes before new messages
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 2, 4: 2}, attempts)
+}
+
+func TestAckSourceDeadLetter(t *testing.T) {
+	b := newMemBroker(1, 2, 3)
+	b.Close()
+
+	var dead []Failure[int]
+	it := AckSource(b.Messages(), AckPolicy{MaxAttempts: 3}, func(f Failure[int]) { dead = append(dead, f) })
+
+	var done []int
+	EachAck(it, func(v int) error {
+		if v == 2 {
+			return errors.New("fail")
+		}
+		done = append(done, v)
+		return nil
+	})
+	require.Equal(t, []int{1, 3}, done)
+	require.Equal(t, []Failure[int]{{Value: 2, Err: ErrNacked, Attempts: 3}}, dead)
+}
+
+func TestAckSourceTimeout(t *testing.T) {
+	clock := newFakeClock(false)
+	b := newMemBroker(1)
+
+	var dead []Failure[int]
+	it := AckSource(b.Messages(), AckPolicy{Timeout: time.Second, MaxAttempts: 2},
+		func(f Failure[int]) { dead = append(dead, f) }, WithClock(clock))
+
+	m, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, Message[int]{Value: 1, Attempt: 1}, Message[int]{Value: m.Value, Attempt: m.Attempt})
+
+	// not acked in time
+	go func() {
+		clock.WaitCalls(1)
+		clock.Advance(time.Second)
+	}()
+	m, ok = it.Next()
+	require.True(t, ok)
+	require.Equal(t, 1, m.Value)
+	require.Equal(t, 2, m.Attempt)
+
+	go func() {
+		clock.WaitCalls(2)
+		clock.Advance(time.Second)
+		b.Close()
+	}()
+	_, ok = it.Next()
+	require.False(t, ok)
+	require.Equal(t, []Failure[int]{{Value: 1, Err: ErrAckTimeout, Attempts: 2}}, dead)
+}
+
+func TestAckSourceLateAck(t *testing.T) {
+	clock := newFakeClock(false)
+	b := newMemBroker(1)
+	it := AckSource(b.Messages(), AckPolicy{Timeout: time.Second}, nil, WithClock(clock))
+
+	first, ok := it.Next()
+	require.True(t, ok)
+
+	go func() {
+		clock.WaitCalls(1)
+		clock.Advance(time.Second)
+	}()
+	second, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, 2, second.Attempt)
+
+	// late ack of the timed-out delivery settles the message
+	first.Ack()
+	second.Nack()
+	first.Nack()
+	b.Close()
+
+	_, ok = it.Next()
+	require.False(t, ok)
+}
+
+func TestMapMessage(t *testing.T) {
+	b := newMemBroker(1, 2, 3, 4, 5)
+	b.Close()
+
+	var mu sync.Mutex
+	attempts := map[int]int{}
+	mapper := func(v int) (string, error) {
+		mu.Lock()
+		attempts[v]++
+		n := attempts[v]
+		mu.Unlock()
+
+		switch {
+		case v == 2 && n == 1:
+			return "", errors.New("fail")
+		case v == 4 && n < 3:
+			panic("crash")
+		}
+		return fmt.Sprint(v), nil
+	}
+
+	var got []string
+	it := MapMessage(AckSource(b.Messages(), AckPolicy{}, nil), mapper, WithConcurrency(2))
+	require.NoError(t, SinkAck(it, func(s string) error {
+		got = append(got, s)
+		return nil
+	}))
+	require.ElementsMatch(t, []string{"1", "2", "3", "4", "5"}, got)
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 1, 4: 3, 5: 1}, attempts)
+}
+
+func TestSinkAck(t *testing.T) {
+	b := newMemBroker(1, 2, 3)
+	b.Close()
+
+	var dead []Failure[int]
+	it := AckSource(b.Messages(), AckPolicy{}, func(f Failure[int]) { dead = append(dead, f) })
+
+	fail := errors.New("fail")
+	var got []int
+	require.ErrorIs(t, SinkAck(it, func(v int) error {
+		if v == 2 {
+			return fail
+		}
+		got = append(got, v)
+		return nil
+	}), fail)
+	require.Equal(t, []int{1}, got)
+
+	// the failed message can not be redelivered by the closed source, so it is dead, the rest stays in the broker
+	require.Equal(t, []Failure[int]{{Value: 2, Err: ErrNacked, Attempts: 1}}, dead)
+	require.Equal(t, []int{3}, C(b.Messages()).Slice())
+}
+
+func TestAckSourceCloseDeadLetter(t *testing.T) {
+	b := newMemBroker(1, 2, 3)
+	var dead []Failure[int]
+	it := AckSource(b.Messages(), AckPolicy{}, func(f Failure[int]) { dead = append(dead, f) })
+
+	m1, _ := it.Next()
+	m2, _ := it.Next()
+	m3, _ := it.Next()
+	m1.Ack()
+	m2.Nack()
+	Close(it)
+	m3.Ack() // too late
+
+	require.Equal(t, []Failure[int]{
+		{Value: 2, Err: ErrNacked, Attempts: 1},
+		{Value: 3, Err: ErrAckClosed, Attempts: 1},
+	}, dead)
+	_, ok := it.Next()
+	require.False(t, ok)
+}
+
+func TestAckSourceBatches(t *testing.T) {
+	b := newMemBroker(1, 2, 3)
+	b.Close()
+
+	// the last partial batch is held until its message times out and comes back
+	seen := map[int]int{}
+	it := Chunk(AckSource(b.Messages(), AckPolicy{Timeout: 10 * time.Millisecond}, nil), 2)
+	for batch, ok := it.Next(); ok; batch, ok = it.Next() {
+		for _, m := range batch {
+			seen[m.Value]++
+			m.Ack()
+		}
+	}
+	require.Equal(t, []int{1, 2, 3}, Sorted(M(seen).Keys()).Slice())
+	require.GreaterOrEqual(t, seen[3], 2, "held message is delivered again")
+}
+
+func TestAckSourceClose(t *testing.T) {
+	b := newMemBroker(1)
+	it := AckSource(b.Messages(), AckPolicy{}, nil)
+
+	_, ok := it.Next()
+	require.True(t, ok)
+
+	// blocked waiting for the held message
+	done := make(chan bool)
+	go func() {
+		_, ok := it.Next()
+		done <- ok
+	}()
+	Close(it)
+	require.False(t, <-done)
+}
+
+func TestMapMessageClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	b := newMemBroker(1, 2, 3) // never closed
+	fail := errors.New("fail")
+	it := MapMessage(AckSource(b.Messages(), AckPolicy{}, nil), func(v int) (int, error) { return v, nil }, WithConcurrency(2))
+	require.ErrorIs(t, SinkAck(it, func(int) error { return fail }), fail)
+	waitGoroutines(t, before)
+}
+
+func TestMapMessagePoison(t *testing.T) {
+	b := newMemBroker(1, 2)
+	b.Close()
+
+	var dead []Failure[int]
+	var got []int
+	it := MapMessage(AckSource(b.Messages(), AckPolicy{}, func(f Failure[int]) { dead = append(dead, f) }),
+		func(v int) (int, error) {
+			if v == 1 {
+				panic("poison")
+			}
+			return v, nil
+		})
+	require.NoError(t, SinkAck(it, func(v int) error {
+		got = append(got, v)
+		return nil
+	}))
+	require.Equal(t, []int{2}, got)
+	require.Equal(t, []Failure[int]{{Value: 1, Err: ErrNacked, Attempts: DefaultMaxAttempts}}, dead)
+}
@@ -1,23 +1,58 @@
 package iter
 
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// C iterate channel until it is closed, the iterator is one-shot
+// position is number of received elements, Restore() receives and drops elements to replay a producer from its start
 func C[T any](ch <-chan T) Iterator[T] {
-	return &withNext[T]{
-		next: func() (v T, ok bool) {
-			v, ok = <-ch
-			return v, ok
+	var pos int64
+	return &positioner[T]{
+		withNext: &withNext[T]{
+			name: "chan",
+			next: func() (v T, ok bool) {
+				v, ok = <-ch
+				if ok {
+					pos++
+				}
+				return v, ok
+			},
+			hint: func() bounds { return bounds{len(ch), -1, false} },
+		},
+		position: func() int64 { return pos },
+		restore: func(p int64) error {
+			for pos < p {
+				if _, ok := <-ch; !ok {
+					return io.ErrUnexpectedEOF
+				}
+				pos++
+			}
+			if pos > p {
+				return fmt.Errorf("restore %d: %w", p, ErrNotPositioner)
+			}
+			return nil
 		},
 	}
 }
 
 func fanOut[T any](it Iterator[T], fn func(T)) {
-	q := newQueue[chan T]()
+	st := newStage("fanOut")
+	q := newQueue[chan T](newOptions())
+	q.stage = st
 
+	done := st.goroutine()
 	go func() {
 		defer q.Close()
+		defer done()
 		for v, ok := it.Next(); ok; v, ok = it.Next() {
 			ch := q.Push(make(chan T))
 			v := v
+			done := st.goroutine()
 			go func() {
+				defer done()
 				ch <- v
 				close(ch)
 			}()
@@ -26,6 +61,43 @@ func fanOut[T any](it Iterator[T], fn func(T)) {
 
 	for ch, ok := q.Pop(); ok; ch, ok = q.Pop() {
 		v := <-ch
+		start := st.start()
 		fn(v)
+		st.element(start)
 	}
 }
+
+// pump pull elements from it in background until ctx is done
+// so that consumer can wait on them with timers or other channels
+func pump[T any](ctx context.Context, it Iterator[T]) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// pumpN is pump() pulling up to buffer elements ahead, it is closed by the pumping goroutine when it returns
+// so that Close() of it does not race with its Next()
+func pumpN[T any](ctx context.Context, it Iterator[T], buffer int) <-chan T {
+	ch := make(chan T, buffer)
+	go func() {
+		defer close(ch)
+		defer Close(it)
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
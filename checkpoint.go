@@ -0,0 +1,328 @@
+package iter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrNotPositioner = errors.New("source is not a Positioner")
+	ErrTrackMismatch = errors.New("elements do not match the tracked source one to one")
+)
+
+// Positioner is implemented by sources which can report and restore their position
+// S(), Of(), C() and Lines() are positioners
+type Positioner interface {
+	Position() int64         // position after the last element returned by Next()
+	Restore(pos int64) error // continue from position returned by Position()
+}
+
+type positioner[T any] struct {
+	*withNext[T]
+	position func() int64
+	restore  func(int64) error
+}
+
+func (it *positioner[T]) Position() int64         { return it.position() }
+func (it *positioner[T]) Restore(pos int64) error { return it.restore(pos) }
+
+func (it *sliceIter[T]) Position() int64 { return int64(it.front) }
+func (it *sliceIter[T]) Restore(pos int64) error {
+	if pos < 0 || pos > int64(it.back) {
+		return fmt.Errorf("restore %d: out of range [0, %d]", pos, it.back)
+	}
+	it.front = int(pos)
+	return nil
+}
+
+// LinesIterator is returned by Lines(), Err() return the read error which ended the iteration
+type LinesIterator interface {
+	Iterator[string]
+	Positioner
+	Err() error
+}
+
+type lines struct {
+	*positioner[string]
+	err error
+}
+
+func (it *lines) Err() error { return it.err }
+
+// Lines iterate lines of r without line endings, position is the byte offset after the line
+// Restore() seeks r if it is an io.Seeker, otherwise skips bytes before the first Next()
+// read error other than io.EOF ends the iteration and is returned by Err(), the partial line is dropped
+func Lines(r io.Reader) LinesIterator {
+	br := bufio.NewReader(r)
+	var pos int64
+	started := false
+
+	it := &lines{}
+	it.positioner = &positioner[string]{
+		withNext: &withNext[string]{
+			name: "lines",
+			next: func() (string, bool) {
+				if it.err != nil {
+					return "", false
+				}
+
+				started = true
+				line, err := br.ReadString('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					it.err = err
+					return "", false
+				}
+				if line == "" {
+					return "", false
+				}
+
+				pos += int64(len(line))
+				line = strings.TrimSuffix(line, "\n")
+				return strings.TrimSuffix(line, "\r"), true
+			},
+		},
+		position: func() int64 { return pos },
+		restore: func(p int64) error {
+			if s, ok := r.(io.Seeker); ok {
+				if _, err := s.Seek(p, io.SeekStart); err != nil {
+					return err
+				}
+				br.Reset(r)
+				pos = p
+				return nil
+			}
+
+			if started || p < pos {
+				return fmt.Errorf("restore %d: %w", p, ErrNotPositioner)
+			}
+			n, err := br.Discard(int(p - pos))
+			pos += int64(n)
+			return err
+		},
+	}
+	return it
+}
+
+// Track record positions of elements pulled from src for Checkpoint()
+type Track[T any] struct {
+	*withNext[T]
+	src Positioner
+
+	mu        sync.Mutex
+	positions []int64
+}
+
+// NewTrack wrap src which must be a Positioner, build the pipeline on the returned iterator
+func NewTrack[T any](src Iterator[T]) (*Track[T], error) {
+	s, ok := src.(Positioner)
+	if !ok {
+		return nil, ErrNotPositioner
+	}
+
+	t := &Track[T]{src: s}
+	t.withNext = &withNext[T]{
+		name:    "track",
+		parents: []any{src},
+		next: func() (T, bool) {
+			v, ok := src.Next()
+			if ok {
+				t.mu.Lock()
+				t.positions = append(t.positions, s.Position())
+				t.mu.Unlock()
+			}
+			return v, ok
+		},
+		close: func() { Close(src) },
+		hint:  func() bounds { return sizeOf(src) },
+	}
+	return t, nil
+}
+
+// pop return position after the oldest element not yet processed
+func (t *Track[T]) pop() (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.positions) == 0 {
+		return 0, false
+	}
+	pos := t.positions[0]
+	t.positions = t.positions[1:]
+	return pos, true
+}
+
+// left return number of elements pulled but not yet processed
+func (t *Track[T]) left() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.positions)
+}
+
+// err return read error of the tracked source
+func (t *Track[T]) err() error {
+	if e, ok := t.src.(interface{ Err() error }); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+// CheckpointStore persist position of a source
+type CheckpointStore interface {
+	Save(pos int64) error
+	Load() (pos int64, ok bool, err error)
+}
+
+// CheckpointIterator is returned by Checkpoint(), Err() return error of the store or the source which ended the iteration
+type CheckpointIterator[T any] interface {
+	Iterator[T]
+	Err() error
+}
+
+type checkpoint[T any] struct {
+	*withNext[T]
+	err error
+}
+
+func (it *checkpoint[T]) Err() error { return it.err }
+
+// Checkpoint save position of the source tracked by t after elements of it the consumer has finished with
+// an element is finished when the next one is requested, position is saved every n elements and when it ends
+// only 1:1 stages such as Map() may be between t and it: k-th element of it must come from k-th element of t
+// Filter(), FilterMap(), FlatMap() and the like break the positions, which is reported as ErrTrackMismatch
+// elements are processed at least once after Resume(), the one being processed at crash is processed again
+func Checkpoint[T, S any](it Iterator[T], t *Track[S], store CheckpointStore, every int) CheckpointIterator[T] {
+	every = max(every, 1)
+	c := &checkpoint[T]{}
+	var last int64
+	pending, started, done := 0, false, false
+
+	save := func() bool {
+		if pending == 0 {
+			return true
+		}
+		if err := store.Save(last); err != nil {
+			c.err = err
+			return false
+		}
+		pending = 0
+		return true
+	}
+	fail := func(err error) (r T, ok bool) {
+		c.err = err
+		done = true
+		Close(it)
+		return r, false
+	}
+
+	c.withNext = &withNext[T]{
+		name:    "checkpoint",
+		parents: []any{it},
+		next: func() (r T, ok bool) {
+			if done {
+				return r, false
+			}
+
+			if started {
+				pos, ok := t.pop()
+				if !ok {
+					return fail(ErrTrackMismatch) // more elements than the source
+				}
+				last = pos
+				pending++
+				if pending >= every && !save() {
+					return fail(c.err)
+				}
+			}
+			started = true
+
+			v, ok := it.Next()
+			if !ok {
+				done = true
+				if t.left() > 0 {
+					c.err = ErrTrackMismatch // fewer elements than the source
+					return r, false
+				}
+				if save() {
+					c.err = t.err()
+				}
+				return r, false
+			}
+
+			return v, true
+		},
+		close: func() { Close(it) },
+		hint:  func() bounds { return sizeOf(it) },
+	}
+	return c
+}
+
+// Resume restore src to the position saved in store before the first Next(), src is unchanged when nothing is saved
+// src is a Positioner or Track of it
+func Resume[T any](src Iterator[T], store CheckpointStore) error {
+	var p Positioner
+	switch s := src.(type) {
+	case *Track[T]:
+		p = s.src
+	case Positioner:
+		p = s
+	default:
+		return ErrNotPositioner
+	}
+
+	pos, ok, err := store.Load()
+	if err != nil || !ok {
+		return err
+	}
+	return p.Restore(pos)
+}
+
+// FileStore is CheckpointStore saving position in a file, the file is replaced atomically by rename
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore { return &FileStore{path: path} }
+
+func (s *FileStore) Save(pos int64) error {
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(strconv.FormatInt(pos, 10)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (s *FileStore) Load() (int64, bool, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	pos, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("checkpoint %s: %w", s.path, err)
+	}
+	return pos, true, nil
+}
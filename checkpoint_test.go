@@ -0,0 +1,213 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	tests := [...]struct {
+		name      string
+		input     string
+		want      []string
+		positions []int64
+	}{
+		{"lf", "a\nbb\n", []string{"a", "bb"}, []int64{2, 5}},
+		{"crlf", "a\r\nbb\r\n", []string{"a", "bb"}, []int64{3, 7}},
+		{"no trailing newline", "a\nbb", []string{"a", "bb"}, []int64{2, 4}},
+		{"empty lines", "\n\nx", []string{"", "", "x"}, []int64{1, 2, 3}},
+		{"empty", "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := Lines(strings.NewReader(tt.input))
+			var got []string
+			var positions []int64
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				got = append(got, v)
+				positions = append(positions, it.(Positioner).Position())
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.positions, positions)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("lines seeker", func(t *testing.T) {
+		it := Lines(strings.NewReader("a\nb\nc\n"))
+		it.Next()
+		it.Next()
+		require.NoError(t, it.(Positioner).Restore(2))
+		require.Equal(t, []string{"b", "c"}, it.Slice())
+	})
+
+	t.Run("lines reader", func(t *testing.T) {
+		it := Lines(io.MultiReader(strings.NewReader("a\nb\nc\n")))
+		require.NoError(t, it.(Positioner).Restore(4))
+		require.Equal(t, []string{"c"}, it.Slice())
+		require.ErrorIs(t, it.(Positioner).Restore(0), ErrNotPositioner)
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		it := Of(1, 2, 3, 4)
+		require.NoError(t, it.(Positioner).Restore(3))
+		require.Equal(t, []int{4}, it.Slice())
+		require.Error(t, it.(Positioner).Restore(5))
+	})
+
+	t.Run("chan", func(t *testing.T) {
+		it := C(feed(1, 2, 3, 4))
+		require.NoError(t, it.(Positioner).Restore(2))
+		require.Equal(t, []int{3, 4}, it.Slice())
+		require.Equal(t, int64(4), it.(Positioner).Position())
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(filepath.Join(dir, "pos"))
+
+	_, ok, err := store.Load()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, store.Save(10))
+	require.NoError(t, store.Save(42))
+	pos, ok, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(42), pos)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files should be renamed or removed")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pos"), []byte("x"), 0o644))
+	_, _, err = store.Load()
+	require.Error(t, err)
+}
+
+func TestCheckpointResume(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&input, "line %d\n", i)
+	}
+	store := NewFileStore(filepath.Join(t.TempDir(), "pos"))
+	processed := map[string]int{}
+
+	run := func(limit int) {
+		src, err := NewTrack(Lines(strings.NewReader(input.String())))
+		require.NoError(t, err)
+		require.NoError(t, Resume[string](src, store))
+
+		it := Checkpoint(Map(src, strings.ToUpper, WithBuffer(8)), src, store, 10)
+		n := 0
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			processed[v]++
+			if n++; n == limit {
+				Close[string](it) // crash
+				break
+			}
+		}
+		require.NoError(t, it.Err())
+	}
+
+	run(37)
+	pos, _, _ := store.Load()
+	require.Equal(t, int64(len("line 0\n")*10+len("line 10\n")*20), pos, "30 lines are finished")
+
+	run(-1)
+	pos, _, _ = store.Load()
+	require.Equal(t, int64(input.Len()), pos)
+
+	require.Len(t, processed, 100)
+	for i := 0; i < 100; i++ {
+		want := 1
+		if i >= 30 && i < 37 {
+			want = 2
+		}
+		require.Equal(t, want, processed[fmt.Sprintf("LINE %d", i)], "line %d", i)
+	}
+}
+
+type failingStore struct{ CheckpointStore }
+
+func (failingStore) Save(int64) error { return errors.New("disk full") }
+
+func TestCheckpointSaveError(t *testing.T) {
+	src, err := NewTrack(Range(0, 10))
+	require.ErrorIs(t, err, ErrNotPositioner)
+	require.Nil(t, src)
+
+	src, err = NewTrack(S([]int{1, 2, 3, 4, 5}))
+	require.NoError(t, err)
+
+	it := Checkpoint[int](src, src, failingStore{}, 2)
+	require.Equal(t, []int{1, 2}, it.Slice())
+	require.EqualError(t, it.Err(), "disk full")
+}
+
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		return n, r.err
+	}
+	return n, err
+}
+
+func TestLinesError(t *testing.T) {
+	errRead := errors.New("connection reset")
+
+	it := Lines(&failingReader{strings.NewReader("a\nb\npartial"), errRead})
+	require.Equal(t, []string{"a", "b"}, it.Slice())
+	require.ErrorIs(t, it.Err(), errRead)
+	require.Equal(t, int64(4), it.Position())
+
+	it = Lines(strings.NewReader("a\nb"))
+	require.Equal(t, []string{"a", "b"}, it.Slice())
+	require.NoError(t, it.Err())
+
+	// checkpoint saves the position before the error and reports it
+	store := NewFileStore(filepath.Join(t.TempDir(), "pos"))
+	src, err := NewTrack[string](Lines(&failingReader{strings.NewReader("a\nb\npartial"), errRead}))
+	require.NoError(t, err)
+	c := Checkpoint[string](src, src, store, 10)
+	require.Equal(t, []string{"a", "b"}, c.Slice())
+	require.ErrorIs(t, c.Err(), errRead)
+	pos, _, _ := store.Load()
+	require.Equal(t, int64(4), pos)
+}
+
+func TestCheckpointMismatch(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "pos"))
+
+	src, err := NewTrack(Of(1, 2, 3, 4))
+	require.NoError(t, err)
+	c := Checkpoint(src.Filter(Even[int]), src, store, 1)
+	require.Equal(t, []int{2, 4}, c.Slice())
+	require.ErrorIs(t, c.Err(), ErrTrackMismatch)
+
+	src, err = NewTrack(Of(1, 2))
+	require.NoError(t, err)
+	c = Checkpoint(FlatMapSlice(src, func(x int) []int { return []int{x, x} }), src, store, 1)
+	c.Slice()
+	require.ErrorIs(t, c.Err(), ErrTrackMismatch)
+}
+
+func TestResumeNotPositioner(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "pos"))
+	require.ErrorIs(t, Resume(Range(0, 3), store), ErrNotPositioner)
+}
@@ -0,0 +1,15 @@
+package iter
+
+import "time"
+
+// Clock is the time source of time based operators
+// tests can inject a fake one with WithClock() so they don't have to sleep
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
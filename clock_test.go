@@ -0,0 +1,80 @@
+package iter
+
+import (
+	"sync"
+	"time"
+)
+
+// fakeClock is a manual clock for tests
+// with auto, After() advance the clock and fire immediately
+type fakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	auto   bool
+	calls  int
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(auto bool) *fakeClock {
+	c := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), auto: auto}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	defer c.cond.Broadcast()
+
+	ch := make(chan time.Time, 1)
+	if c.auto && d > 0 {
+		c.now = c.now.Add(d)
+	}
+	if c.auto || d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance move the clock and fire expired timers
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			timers = append(timers, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = timers
+}
+
+// WaitCalls block until After() called n times in total
+func (c *fakeClock) WaitCalls(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.calls < n {
+		c.cond.Wait()
+	}
+}
@@ -0,0 +1,205 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stage is a node of the pipeline graph returned by Describe()
+type Stage struct {
+	ID       int
+	Name     string
+	Elements int64 // elements produced so far, -1 if the stage is not counted
+	Parents  []int // IDs of upstream stages
+}
+
+// Graph is the pipeline behind an iterator, stages are in upstream first order and the last one is the described iterator
+type Graph struct {
+	Stages []Stage
+}
+
+// describer is implemented by iterators which record their stage name and upstreams
+// key identify the stage when it is reached from several downstreams
+type describer interface {
+	describe() (key any, name string, parents []any, elements int64)
+}
+
+// describe set stage name and upstreams of it built by lazy() or another operator
+// like Named() it is not fused with following operators to keep the name
+func describe[T any](it Iterator[T], name string, parents ...any) Iterator[T] {
+	if w, ok := it.(*withNext[T]); ok {
+		w.name, w.parents, w.fused = name, parents, nil
+	}
+	return it
+}
+
+// ups return its as upstreams
+func ups[S ~[]E, E any](its S) []any {
+	r := make([]any, len(its))
+	for i, it := range its {
+		r[i] = it
+	}
+	return r
+}
+
+func (it *withNext[T]) describe() (any, string, []any, int64) {
+	name := it.name
+	switch {
+	case name != "":
+	case it.fused != nil:
+		name = strings.Join(it.fused.names, "+")
+	case it.stage != nil:
+		name = it.stage.Name()
+	default:
+		name = "iterator"
+	}
+
+	elements := int64(-1)
+	if it.stage != nil {
+		elements = it.stage.elements.Load()
+	}
+	return it, name, it.parents, elements
+}
+
+func (it *withNext[T]) String() string { return Describe[T](it).String() }
+
+// describeM set stage name and upstreams of MapIterator built by lazyM()
+func describeM[K comparable, V any](m MapIterator[K, V], name string, parents ...any) MapIterator[K, V] {
+	if mi, ok := m.(*mapIter[K, V]); ok {
+		mi.name, mi.parents = name, parents
+	}
+	return m
+}
+
+func (it *withNext2[K, V]) describe() (any, string, []any, int64) { return it, it.name, it.parents, -1 }
+func (it *withNext2[K, V]) String() string                        { return describeAny(it).String() }
+
+func (m *mapIter[K, V]) describe() (any, string, []any, int64) {
+	name := m.name
+	if name == "" {
+		name = "map"
+	}
+	return m, name, m.parents, -1
+}
+
+// Describe return stages chained behind it, iterators not built by this package are shown with their type
+func Describe[T any](it Iterator[T]) *Graph { return describeAny(it) }
+
+func describeAny(it any) *Graph {
+	g := &Graph{}
+	ids := map[any]int{}
+
+	var walk func(v any) int
+	walk = func(v any) int {
+		d, ok := v.(describer)
+		if !ok {
+			g.Stages = append(g.Stages, Stage{ID: len(g.Stages), Name: fmt.Sprintf("%T", v), Elements: -1})
+			return len(g.Stages) - 1
+		}
+
+		key, name, parents, elements := d.describe()
+		if id, ok := ids[key]; ok {
+			return id
+		}
+
+		var pids []int
+		for _, p := range parents {
+			pids = append(pids, walk(p))
+		}
+
+		id := len(g.Stages)
+		ids[key] = id
+		g.Stages = append(g.Stages, Stage{ID: id, Name: name, Elements: elements, Parents: pids})
+		return id
+	}
+	walk(it)
+
+	return g
+}
+
+func (s Stage) label() string {
+	if s.Elements < 0 {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (%d)", s.Name, s.Elements)
+}
+
+// String return the chain of stages like "slice -> map -> filter", stages with several upstreams are "[a, b] -> concat"
+func (g *Graph) String() string {
+	if len(g.Stages) == 0 {
+		return ""
+	}
+
+	var chain func(id int) string
+	chain = func(id int) string {
+		s := g.Stages[id]
+		switch len(s.Parents) {
+		case 0:
+			return s.Name
+		case 1:
+			return chain(s.Parents[0]) + " -> " + s.Name
+		}
+
+		parents := make([]string, len(s.Parents))
+		for i, p := range s.Parents {
+			parents[i] = chain(p)
+		}
+		return "[" + strings.Join(parents, ", ") + "] -> " + s.Name
+	}
+	return chain(len(g.Stages) - 1)
+}
+
+// Tree return stages as a tree from the described iterator to its sources with element counts
+func (g *Graph) Tree() string {
+	if len(g.Stages) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	var walk func(id int, prefix, branch, indent string)
+	walk = func(id int, prefix, branch, indent string) {
+		s := g.Stages[id]
+		b.WriteString(prefix + branch + s.label() + "\n")
+		for i, p := range s.Parents {
+			if i == len(s.Parents)-1 {
+				walk(p, prefix+indent, "└── ", "    ")
+			} else {
+				walk(p, prefix+indent, "├── ", "│   ")
+			}
+		}
+	}
+	walk(len(g.Stages)-1, "", "", "")
+
+	return b.String()
+}
+
+// DOT return the graph in Graphviz DOT format
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n\trankdir=LR;\n")
+	for _, s := range g.Stages {
+		fmt.Fprintf(&b, "\tn%d [label=%q];\n", s.ID, s.label())
+	}
+	for _, s := range g.Stages {
+		for _, p := range s.Parents {
+			fmt.Fprintf(&b, "\tn%d -> n%d;\n", p, s.ID)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid return the graph in Mermaid flowchart format
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, s := range g.Stages {
+		fmt.Fprintf(&b, "\tn%d[\"%s\"]\n", s.ID, strings.ReplaceAll(s.label(), `"`, "#quot;"))
+	}
+	for _, s := range g.Stages {
+		for _, p := range s.Parents {
+			fmt.Fprintf(&b, "\tn%d --> n%d\n", p, s.ID)
+		}
+	}
+	return b.String()
+}
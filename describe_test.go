@@ -0,0 +1,105 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type foreignIter struct{ Iterator[int] }
+
+func TestDescribeString(t *testing.T) {
+	tests := [...]struct {
+		name string
+		it   func() fmt.Stringer
+		want string
+	}{
+		{"source", func() fmt.Stringer { return S([]int{1}).(fmt.Stringer) }, "slice"},
+		{"chain", func() fmt.Stringer {
+			return Range(0, 10).Map(Multiply(2)).Filter(Even[int]).Skip(1).(fmt.Stringer)
+		}, "range -> map -> filter -> skip"},
+		{"fused", func() fmt.Stringer {
+			return Map(Of(1, 2).Filter(Even[int]), Multiply(2), WithSequential()).(fmt.Stringer)
+		}, "slice -> filter+map"},
+		{"concat", func() fmt.Stringer {
+			return Chunk(Concat(Of(1), Range(0, 3)), 2).(fmt.Stringer)
+		}, "[slice, range] -> concat -> chunk"},
+		{"named", func() fmt.Stringer {
+			return Named("double", Of(1, 2).Map(Multiply(2))).(fmt.Stringer)
+		}, "slice -> double"},
+		{"map", func() fmt.Stringer { return Sorted(M(map[int]int{}).Keys()).(fmt.Stringer) }, "map -> keys -> sorted"},
+		{"map ops", func() fmt.Stringer {
+			return OmitKeys(MergeMaps(nil, M(map[int]int{}), M(map[int]int{})), 1).Items().(fmt.Stringer)
+		}, "[map, map] -> mergeMaps -> omitKeys -> items"},
+		{"join", func() fmt.Stringer {
+			return HashJoin(Of(1), Of("a"), func(x int) int { return x }, func(string) int { return 0 }).(fmt.Stringer)
+		}, "[slice, slice] -> hashJoin"},
+		{"kv", func() fmt.Stringer {
+			return MapValues(M(map[int]int{}).KV(), func(k, v int) int { return v }).(fmt.Stringer)
+		}, "map -> items -> kv -> map"},
+		{"foreign", func() fmt.Stringer { return Map[int](foreignIter{Of(1)}, Multiply(2)).(fmt.Stringer) }, "iter.foreignIter -> map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.it().String())
+		})
+	}
+}
+
+func TestDescribeSharedUpstream(t *testing.T) {
+	src := Range(0, 4)
+	g := Describe(Interleave(src, src))
+
+	require.Len(t, g.Stages, 2)
+	require.Equal(t, []int{0, 0}, g.Stages[1].Parents)
+}
+
+func TestDescribeElements(t *testing.T) {
+	it := Named("evens", Range(0, 10).Filter(Even[int]))
+	it.Next()
+	it.Next()
+
+	g := Describe(it)
+	require.Equal(t, []Stage{
+		{ID: 0, Name: "range", Elements: -1},
+		{ID: 1, Name: "evens", Elements: 2, Parents: []int{0}},
+	}, g.Stages)
+}
+
+func TestDescribeExport(t *testing.T) {
+	it := Concat(Of(1, 2), Of(3)).Filter(Odd[int])
+	require.Equal(t, []int{1, 3}, it.Slice())
+	g := Describe(it)
+
+	require.Equal(t, strings.Join([]string{
+		"filter (2)",
+		"└── concat (3)",
+		"    ├── slice",
+		"    └── slice",
+		"",
+	}, "\n"), g.Tree())
+
+	require.Equal(t, `digraph pipeline {
+	rankdir=LR;
+	n0 [label="slice"];
+	n1 [label="slice"];
+	n2 [label="concat (3)"];
+	n3 [label="filter (2)"];
+	n0 -> n2;
+	n1 -> n2;
+	n2 -> n3;
+}
+`, g.DOT())
+
+	require.Equal(t, `graph LR
+	n0["slice"]
+	n1["slice"]
+	n2["concat (3)"]
+	n3["filter (2)"]
+	n0 --> n2
+	n1 --> n2
+	n2 --> n3
+`, g.Mermaid())
+}
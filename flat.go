@@ -0,0 +1,65 @@
+package iter
+
+// FlatMap map each element to an iterator and flatten them in order
+// with WithConcurrency() inner iterators are created and drained concurrently like Map()
+func FlatMap[T1, T2 any](it Iterator[T1], fn func(T1) Iterator[T2], opts ...Option) Iterator[T2] {
+	if newOptions(opts...).concurrency > 0 {
+		return FlattenSlice(Map(it, func(v T1) []T2 { return slice(fn(v)) }, opts...))
+	}
+
+	return Flatten(Map(it, fn, sequential(opts)...))
+}
+
+// FlatMapSlice map each element to a slice and flatten them in order
+func FlatMapSlice[T1, T2 any](it Iterator[T1], fn func(T1) []T2, opts ...Option) Iterator[T2] {
+	if newOptions(opts...).concurrency > 0 {
+		return FlattenSlice(Map(it, fn, opts...))
+	}
+
+	return FlattenSlice(Map(it, fn, sequential(opts)...))
+}
+
+// Flatten concatenate iterators in order
+func Flatten[T any](it Iterator[Iterator[T]]) Iterator[T] {
+	var cur Iterator[T]
+
+	return &withNext[T]{
+		name:    "flatten",
+		parents: []any{it},
+		next: func() (r T, ok bool) {
+			for {
+				if cur != nil {
+					if v, ok := cur.Next(); ok {
+						return v, true
+					}
+				}
+
+				c, ok := it.Next()
+				if !ok {
+					return r, false
+				}
+				cur = c
+			}
+		},
+		close: func() {
+			if cur != nil {
+				Close(cur)
+			}
+			Close(it)
+		},
+	}
+}
+
+// FlattenSlice concatenate slices in order, inverse of Chunk()
+func FlattenSlice[T any](it Iterator[[]T]) Iterator[T] {
+	return Flatten(Map(it, func(s []T) Iterator[T] { return S(s) }, WithSequential()))
+}
+
+// Scan emit running accumulations of fn starting from init
+func Scan[T, A any](it Iterator[T], init A, fn func(A, T) A) Iterator[A] {
+	acc := init
+	return Map(it, func(v T) A {
+		acc = fn(acc, v)
+		return acc
+	}, WithSequential())
+}
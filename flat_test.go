@@ -0,0 +1,48 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatMap(t *testing.T) {
+	lines := []string{"hello world", "", "iterator in go"}
+	want := []string{"hello", "world", "iterator", "in", "go"}
+	words := func(s string) Iterator[string] { return S(strings.Fields(s)) }
+
+	require.Equal(t, want, FlatMap(S(lines), words).Slice())
+	require.Equal(t, want, FlatMap(S(lines), words, WithConcurrency(2)).Slice())
+	require.Equal(t, want, FlatMapSlice(S(lines), strings.Fields).Slice())
+	require.Equal(t, want, FlatMapSlice(S(lines), strings.Fields, WithConcurrency(2)).Slice())
+}
+
+func TestFlatMapConcurrency(t *testing.T) {
+	probe := newConcurrencyProbe(2)
+	fn := func(x int) Iterator[int] {
+		probe.enter()
+		defer probe.leave()
+		return Of(x, x)
+	}
+
+	got := FlatMap(Range(0, 20), fn, WithConcurrency(3)).Slice()
+	require.Len(t, got, 40)
+	require.Equal(t, []int{0, 0, 1, 1, 2, 2}, got[:6])
+	require.LessOrEqual(t, probe.Peak(), int32(3))
+	require.GreaterOrEqual(t, probe.Peak(), int32(2), "inner iterators should be created concurrently")
+}
+
+func TestFlatten(t *testing.T) {
+	require.Equal(t, []int{1, 2, 3, 4}, Flatten(Of(Of(1, 2), Of[int](), Of(3), Of(4))).Slice())
+	require.Empty(t, Flatten(Of[Iterator[int]]()).Slice())
+
+	s := []int{1, 2, 3, 4, 5}
+	require.Equal(t, s, FlattenSlice(Chunk(S(s), 2)).Slice())
+}
+
+func TestScan(t *testing.T) {
+	require.Equal(t, []int{1, 3, 6, 10}, Scan(Of(1, 2, 3, 4), 0, Add[int]).Slice())
+	require.Equal(t, []string{"a", "ab", "abc"}, Scan(Of('a', 'b', 'c'), "", func(acc string, r rune) string { return acc + string(r) }).Slice())
+	require.Empty(t, Scan(Of[int](), 0, Add[int]).Slice())
+}
@@ -0,0 +1,87 @@
+// Package fn provides predicates, comparators and function combinators to use with iter
+package fn
+
+import "cmp"
+
+// Identity return x as it is
+func Identity[T any](x T) T { return x }
+
+// Compose return function which applies f then g
+func Compose[T1, T2, T3 any](f func(T1) T2, g func(T2) T3) func(T1) T3 {
+	return func(x T1) T3 { return g(f(x)) }
+}
+
+// Not negate pred
+func Not[T any](pred func(T) bool) func(T) bool {
+	return func(x T) bool { return !pred(x) }
+}
+
+// And return true if all preds return true, it returns true without preds
+func And[T any](preds ...func(T) bool) func(T) bool {
+	return func(x T) bool {
+		for _, pred := range preds {
+			if !pred(x) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or return true if any pred returns true, it returns false without preds
+func Or[T any](preds ...func(T) bool) func(T) bool {
+	return func(x T) bool {
+		for _, pred := range preds {
+			if pred(x) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Equals return predicate which tests x == v
+func Equals[T comparable](v T) func(T) bool {
+	return func(x T) bool { return x == v }
+}
+
+// Between return predicate which tests lo <= x <= hi
+func Between[T cmp.Ordered](lo, hi T) func(T) bool {
+	return func(x T) bool { return cmp.Compare(x, lo) >= 0 && cmp.Compare(x, hi) <= 0 }
+}
+
+// KeyOf return predicate which tests pred on key of x
+func KeyOf[T, K any](key func(T) K, pred func(K) bool) func(T) bool {
+	return func(x T) bool { return pred(key(x)) }
+}
+
+// Ascending compare a and b, NaN is less than any other number
+func Ascending[T cmp.Ordered](a, b T) int { return cmp.Compare(a, b) }
+
+// Descending compare a and b in reverse order
+func Descending[T cmp.Ordered](a, b T) int { return cmp.Compare(b, a) }
+
+// Comparing return comparator which compares keys in ascending order
+func Comparing[T any, K cmp.Ordered](key func(T) K) func(T, T) int {
+	return func(a, b T) int { return cmp.Compare(key(a), key(b)) }
+}
+
+// ThenComparing return comparator which uses next comparators when the previous one returns 0
+func ThenComparing[T any](first func(T, T) int, next ...func(T, T) int) func(T, T) int {
+	return func(a, b T) int {
+		if c := first(a, b); c != 0 {
+			return c
+		}
+		for _, fn := range next {
+			if c := fn(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// Reversed reverse order of comparator
+func Reversed[T any](cmp func(T, T) int) func(T, T) int {
+	return func(a, b T) int { return cmp(b, a) }
+}
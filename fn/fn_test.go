@@ -0,0 +1,112 @@
+package fn
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+func TestPredicates(t *testing.T) {
+	odd := func(x int) bool { return x%2 != 0 }
+	positive := func(x int) bool { return x > 0 }
+
+	type args struct {
+		pred func(int) bool
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []int
+	}{
+		{"not", args{Not(odd)}, []int{-4, -2, 0, 2, 4}},
+		{"and", args{And(odd, positive)}, []int{1, 3}},
+		{"and empty", args{And[int]()}, []int{-4, -3, -2, -1, 0, 1, 2, 3, 4}},
+		{"or", args{Or(odd, Equals(0))}, []int{-3, -1, 0, 1, 3}},
+		{"or empty", args{Or[int]()}, nil},
+		{"equals", args{Equals(-2)}, []int{-2}},
+		{"between", args{Between(-1, 2)}, []int{-1, 0, 1, 2}},
+		{"key", args{KeyOf(func(x int) int { return x * x }, Equals(9))}, []int{-3, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for x := -4; x <= 4; x++ {
+				if tt.args.pred(x) {
+					got = append(got, x)
+				}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBetweenFloat(t *testing.T) {
+	between := Between(-0.5, 0.5)
+	require.True(t, between(-0.5))
+	require.True(t, between(0.25))
+	require.False(t, between(0.51))
+	require.False(t, between(math.NaN()))
+	require.False(t, between(math.Inf(-1)))
+}
+
+func TestCompose(t *testing.T) {
+	f := Compose(func(x int) int { return x * 2 }, strconv.Itoa)
+	require.Equal(t, "-6", f(-3))
+	require.Equal(t, 7, Compose(Identity[int], Identity[int])(7))
+}
+
+func TestComparators(t *testing.T) {
+	require.Equal(t, -1, Ascending(math.MinInt, math.MaxInt))
+	require.Equal(t, 1, Ascending(math.MaxInt64, math.MinInt64))
+	require.Equal(t, 1, Descending(math.MinInt, math.MaxInt))
+	require.Equal(t, -1, Ascending(0.0, 0.5))
+	require.Equal(t, 1, Ascending(-0.25, -0.5))
+	require.Equal(t, 0, Ascending(0.0, math.Copysign(0, -1)))
+	require.Equal(t, -1, Ascending(math.NaN(), math.Inf(-1)))
+	require.Equal(t, 0, Ascending(math.NaN(), math.NaN()))
+
+	s := []float64{0.5, math.Inf(1), -0.5, math.NaN(), 0}
+	slices.SortFunc(s, Ascending[float64])
+	require.True(t, math.IsNaN(s[0]))
+	require.Equal(t, []float64{-0.5, 0, 0.5, math.Inf(1)}, s[1:])
+}
+
+func TestComparing(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"bob", 30}, {"alice", 30}, {"carol", 20}, {"dave", 40}}
+
+	type args struct {
+		cmp func(a, b person) int
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []string
+	}{
+		{"age", args{Comparing(func(p person) int { return p.age })}, []string{"carol", "bob", "alice", "dave"}},
+		{"age then name", args{ThenComparing(
+			Comparing(func(p person) int { return p.age }),
+			Comparing(func(p person) string { return p.name }))}, []string{"carol", "alice", "bob", "dave"}},
+		{"reversed age then name", args{ThenComparing(
+			Reversed(Comparing(func(p person) int { return p.age })),
+			Comparing(func(p person) string { return p.name }))}, []string{"dave", "alice", "bob", "carol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Clone(people)
+			slices.SortStableFunc(s, tt.args.cmp)
+
+			got := make([]string, len(s))
+			for i, p := range s {
+				got[i] = p.name
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package iter
 
 import (
+	"cmp"
+	"context"
 	"strconv"
 
 	"golang.org/x/exp/constraints"
@@ -8,6 +10,8 @@ import (
 )
 
 // NOTE Next()와 Value()가 thread safe하지 않음..
+// Iterator is lazy: constructors and operators pull nothing and start no goroutine until the first Next()
+// Reduce(), Slice(), Each() and EachIdx() consume the iterator
 type Iterator[T any] interface {
 	Next() (T, bool)
 
@@ -25,7 +29,26 @@ type Iterator[T any] interface {
 }
 
 type withNext[T any] struct {
-	next func() (T, bool)
+	next  func() (T, bool)
+	close func()
+	stage *stage
+	fused *fused[T] // set by synchronous element-wise operators so that chained ones are fused
+	hint  func() bounds
+
+	name    string // stage name for Describe(), stage or fused names are used if empty
+	parents []any  // upstream iterators for Describe()
+}
+
+// Closer is implemented by iterators which can release their goroutines and upstreams before drained
+type Closer interface {
+	Close()
+}
+
+// Close close it if it is a Closer
+func Close[T any](it Iterator[T]) {
+	if c, ok := it.(Closer); ok {
+		c.Close()
+	}
 }
 
 func (it *withNext[T]) Next() (T, bool)                       { return it.next() }
@@ -38,30 +61,104 @@ func (it *withNext[T]) Reduce(fn func(T, T) T) T              { return reduce[T]
 func (it *withNext[T]) Slice() (r []T)                        { return slice[T](it) }
 func (it *withNext[T]) Each(fn func(T))                       { each[T](it, fn) }
 func (it *withNext[T]) EachIdx(fn func(int, T))               { eachIdx[T](it, fn) }
+func (it *withNext[T]) Close() {
+	if it.close != nil {
+		it.close()
+	}
+}
 
 // Map map mapper using goroutine
-func Map[T1, T2 any](it Iterator[T1], mapper func(T1) T2) Iterator[T2] {
-	q := newQueue[chan T2]()
-	go func() {
-		defer q.Close()
-		for v, ok := it.Next(); ok; v, ok = it.Next() {
-			ch := q.Push(make(chan T2))
-			v := v
-			go func() {
-				ch <- mapper(v)
-				close(ch)
-			}()
+// WithRateLimit() limit how often mapper is started, WithBuffer() limit how many results are in flight
+// WithConcurrency() limit how many mappers run at the same time
+// WithSequential() call mapper on the consumer goroutine and fuse it with adjacent Filter()
+func Map[T1, T2 any](it Iterator[T1], mapper func(T1) T2, opts ...Option) Iterator[T2] {
+	o := newOptions(opts...)
+	limiter := o.limiter()
+
+	if o.sequential {
+		if m, ok := any(mapper).(func(T1) T1); ok {
+			return any(fuse("map", it, false, func(v T1) (T1, bool) {
+				limiter.Wait()
+				return m(v), true
+			})).(Iterator[T2])
 		}
-	}()
+
+		st := newStage("map")
+		return &withNext[T2]{
+			stage: st,
+			next: func() (r T2, ok bool) {
+				start := st.start()
+				v, ok := it.Next()
+				if !ok {
+					return r, false
+				}
+				limiter.Wait()
+				r = mapper(v)
+				st.element(start)
+				return r, true
+			},
+			close:   func() { Close(it) },
+			hint:    func() bounds { return sizeOf(it) },
+			parents: []any{it},
+		}
+	}
+
+	st := newStage("map")
+	hint, started, pulled := countdown(it)
+	var q *Queue[chan T2]
+	run := func() {
+		started()
+		q = newQueue[chan T2](o)
+		q.stage = st
+		sem := o.semaphore()
+		done := st.goroutine()
+		go func() {
+			defer q.Close()
+			defer done()
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				limiter.Wait()
+				ch := make(chan T2, 1) // buffered not to block mapper when closed
+				if err := q.PushCtx(context.Background(), ch); err != nil {
+					return
+				}
+				v := v
+				sem.acquire()
+				done := st.goroutine()
+				go func() {
+					defer done()
+					start := st.start()
+					r := mapper(v)
+					st.element(start)
+					sem.release()
+					ch <- r
+					close(ch)
+				}()
+			}
+		}()
+	}
 
 	return &withNext[T2]{
+		stage: st,
 		next: func() (r T2, ok bool) {
+			if q == nil {
+				run()
+			}
+
 			ch, ok := q.Pop()
 			if ok {
+				pulled()
 				return <-ch, ok
 			}
 			return r, ok
 		},
+		close: func() {
+			if q != nil {
+				q.Close()
+			}
+			Close(it)
+		},
+		hint:    hint,
+		parents: []any{it},
 	}
 }
 
@@ -74,21 +171,62 @@ type Number interface {
 }
 
 func filter[T any](it Iterator[T], filterer func(T) bool) Iterator[T] {
+	return fuse("filter", it, true, func(v T) (T, bool) { return v, filterer(v) })
+}
+
+// fused is a chain of synchronous filter and map steps over src
+type fused[T any] struct {
+	src       Iterator[T]
+	step      func(T) (T, bool)
+	filtering bool     // any step may drop elements
+	names     []string // names of steps for Describe()
+}
+
+// fuse add step after it, when it is also fused the steps are collapsed into a single closure
+func fuse[T any](name string, it Iterator[T], filtering bool, step func(T) (T, bool)) Iterator[T] {
+	src := it
+	names := []string{name}
+	if w, ok := it.(*withNext[T]); ok && w.fused != nil {
+		src = w.fused.src
+		names = append(slices.Clip(w.fused.names), name)
+		filtering = filtering || w.fused.filtering
+		prev, next := w.fused.step, step
+		step = func(v T) (T, bool) {
+			if v, ok := prev(v); ok {
+				return next(v)
+			}
+			return v, false
+		}
+	}
+
+	st := newStage(name)
 	return &withNext[T]{
+		stage: st,
+		fused: &fused[T]{src: src, step: step, filtering: filtering, names: names},
 		next: func() (r T, ok bool) {
-			for v, ok := it.Next(); ok; v, ok = it.Next() {
-				if ok && filterer(v) {
-					return v, ok
+			start := st.start()
+			for v, ok := src.Next(); ok; v, ok = src.Next() {
+				if v, ok := step(v); ok {
+					st.element(start)
+					return v, true
 				}
 			}
 			return r, false
 		},
+		close: func() { Close(src) },
+		hint: func() bounds {
+			if filtering {
+				return sizeOf(src).atMost()
+			}
+			return sizeOf(src)
+		},
+		parents: []any{src},
 	}
 }
 
 // Sample filter functions
 func Even[T constraints.Integer](x T) bool { return x%2 == 0 }
-func Odd[T constraints.Integer](x T) bool  { return x%2 == 1 }
+func Odd[T constraints.Integer](x T) bool  { return x%2 != 0 }
 
 func reduce[T any](it Iterator[T], reducer func(T, T) T) T {
 	value, ok := it.Next()
@@ -103,57 +241,194 @@ func reduce[T any](it Iterator[T], reducer func(T, T) T) T {
 	return value
 }
 
-func takeWhile[T any](it Iterator[T], take func(T) bool) Iterator[T] {
-	q := newQueue[T]()
-	go func() {
-		defer q.Close()
-		for v, ok := it.Next(); ok; v, ok = it.Next() {
-			if !take(v) {
-				break
+// TakeWhile take elements while take returns true
+// it pulls at most one element past the boundary
+func TakeWhile[T any](it Iterator[T], take func(T) bool, opts ...Option) Iterator[T] {
+	return takeWhile(it, take, opts...)
+}
+
+func takeWhile[T any](it Iterator[T], take func(T) bool, opts ...Option) Iterator[T] {
+	st := newStage("takeWhile")
+	taking := true
+
+	return prefetch(&withNext[T]{
+		stage: st,
+		next: func() (r T, ok bool) {
+			if !taking {
+				return r, false
+			}
+
+			start := st.start()
+			v, ok := it.Next()
+			if !ok || !take(v) {
+				taking = false
+				return r, false
 			}
-			q.Push(v)
-		}
-	}()
 
-	return &withNext[T]{
-		next: func() (T, bool) {
-			v, ok := q.Pop()
-			return v, ok
+			st.element(start)
+			return v, true
 		},
-	}
+		close: func() { Close(it) },
+		hint: func() bounds {
+			if !taking {
+				return exactSize(0)
+			}
+			return sizeOf(it).atMost()
+		},
+		parents: []any{it},
+	}, newOptions(opts...))
 }
 
-func dropWhile[T any](it Iterator[T], drop func(T) bool) Iterator[T] {
-	q := newQueue[T]()
-	go func() {
-		defer q.Close()
+// DropWhile drop elements while drop returns true
+func DropWhile[T any](it Iterator[T], drop func(T) bool, opts ...Option) Iterator[T] {
+	return dropWhile(it, drop, opts...)
+}
 
-		for v, ok := it.Next(); ok; v, ok = it.Next() {
-			if !drop(v) {
-				q.Push(v)
-				break
+func dropWhile[T any](it Iterator[T], drop func(T) bool, opts ...Option) Iterator[T] {
+	st := newStage("dropWhile")
+	dropping := true
+
+	return prefetch(&withNext[T]{
+		stage: st,
+		next: func() (r T, ok bool) {
+			start := st.start()
+			if dropping {
+				dropping = false
+				for v, ok := it.Next(); ok; v, ok = it.Next() {
+					if !drop(v) {
+						st.element(start)
+						return v, true
+					}
+				}
+				return r, false
 			}
-		}
 
-		for v, ok := it.Next(); ok; v, ok = it.Next() {
-			q.Push(v)
-		}
-	}()
+			v, ok := it.Next()
+			if ok {
+				st.element(start)
+			}
+			return v, ok
+		},
+		close: func() { Close(it) },
+		hint: func() bounds {
+			if dropping {
+				return sizeOf(it).atMost()
+			}
+			return sizeOf(it)
+		},
+		parents: []any{it},
+	}, newOptions(opts...))
+}
+
+// prefetch pull it in background into a queue when WithBuffer() is given
+func prefetch[T any](it *withNext[T], o *options) Iterator[T] {
+	if o.buffer <= 0 {
+		return it
+	}
+
+	hint, started, pulled := countdown[T](it)
+	var q *Queue[T]
+	run := func() {
+		started()
+		q = newQueue[T](o)
+		q.stage = it.stage
+		done := it.stage.goroutine()
+		go func() {
+			defer q.Close()
+			defer done()
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				if err := q.PushCtx(context.Background(), v); err != nil {
+					return
+				}
+			}
+		}()
+	}
 
 	return &withNext[T]{
+		stage: it.stage,
 		next: func() (T, bool) {
+			if q == nil {
+				run()
+			}
 			v, ok := q.Pop()
+			if ok {
+				pulled()
+			}
 			return v, ok
 		},
+		close: func() {
+			if q != nil {
+				q.Close()
+			}
+			it.Close()
+		},
+		hint:    hint,
+		name:    "prefetch",
+		parents: []any{it},
 	}
 }
 
 func skip[T any](it Iterator[T], n int) Iterator[T] {
-	for i := 0; i < n; i++ {
-		it.Next()
+	if si, ok := it.(SliceIterator[T]); ok {
+		return describe(lazyHint(func() Iterator[T] {
+			advance(si, n)
+			return si
+		}, func() bounds { return exactSize(si.Len()).sub(n) }), "skip", it)
 	}
 
-	return it
+	skipped := false
+	return &withNext[T]{
+		next: func() (T, bool) {
+			if !skipped {
+				skipped = true
+				for i := 0; i < n; i++ {
+					if _, ok := it.Next(); !ok {
+						break
+					}
+				}
+			}
+			return it.Next()
+		},
+		close: func() { Close(it) },
+		hint: func() bounds {
+			if !skipped {
+				return sizeOf(it).sub(n)
+			}
+			return sizeOf(it)
+		},
+		name:    "skip",
+		parents: []any{it},
+	}
+}
+
+// lazy defer creating iterator with fn until the first Next()
+func lazy[T any](fn func() Iterator[T]) Iterator[T] { return lazyHint(fn, nil) }
+
+// lazyHint is lazy() whose size hint is given by hint until the iterator is created
+func lazyHint[T any](fn func() Iterator[T], hint func() bounds) Iterator[T] {
+	var it Iterator[T]
+	return &withNext[T]{
+		next: func() (T, bool) {
+			if it == nil {
+				it = fn()
+			}
+			return it.Next()
+		},
+		close: func() {
+			if it != nil {
+				Close(it)
+			}
+		},
+		hint: func() bounds {
+			switch {
+			case it != nil:
+				return sizeOf(it)
+			case hint != nil:
+				return hint()
+			}
+			return unknownSize
+		},
+	}
 }
 
 // Sample reducer functions
@@ -162,6 +437,17 @@ func Add[T Number | string](a, b T) T { return a + b }
 func Sum[T Number | string](it Iterator[T]) T { return reduce(it, Add[T]) }
 
 func slice[T any](it Iterator[T]) (r []T) {
+	if si, ok := it.(*sliceIter[T]); ok {
+		if si.Len() > 0 {
+			r = slices.Clone(si.Remaining())
+		}
+		si.front = si.back
+		return r
+	}
+
+	if c := sizeOf(it).capacity(); c > 0 {
+		r = make([]T, 0, c)
+	}
 	for v, ok := it.Next(); ok; v, ok = it.Next() {
 		r = append(r, v)
 	}
@@ -180,38 +466,57 @@ func eachIdx[T any](it Iterator[T], each func(int, T)) {
 	})
 }
 
+// Sorted sort elements on the first Next()
 func Sorted[T constraints.Ordered](it Iterator[T]) Iterator[T] {
-	s := it.Slice()
-	slices.Sort(s)
-	return S(s)
+	return describe(lazyHint(func() Iterator[T] {
+		s := slice(it)
+		slices.Sort(s)
+		return S(s)
+	}, func() bounds { return sizeOf(it) }), "sorted", it)
 }
 
 type Less[T any] func(T, T) int
 
-func Asending[T constraints.Integer | constraints.Float](a, b T) int   { return int(a - b) }
-func Descending[T constraints.Integer | constraints.Float](a, b T) int { return -Asending(a, b) }
+// Asending, Descending compare with cmp.Compare() so they don't overflow, see fn package for more comparators
+func Asending[T constraints.Integer | constraints.Float](a, b T) int   { return cmp.Compare(a, b) }
+func Descending[T constraints.Integer | constraints.Float](a, b T) int { return cmp.Compare(b, a) }
 
+// SortedFunc sort elements with less on the first Next()
 func SortedFunc[T any](it Iterator[T], less Less[T]) Iterator[T] {
-	s := it.Slice()
-	slices.SortFunc(s, less)
-	return S(s)
+	return describe(lazyHint(func() Iterator[T] {
+		s := slice(it)
+		slices.SortFunc(s, less)
+		return S(s)
+	}, func() bounds { return sizeOf(it) }), "sorted", it)
 }
 
-func Concat[T any](it ...Iterator[T]) Iterator[T] {
-	q := newQueue[T]()
-	go func() {
-		defer q.Close()
-		for _, i := range it {
-			for v, ok := i.Next(); ok; v, ok = i.Next() {
-				q.Push(v)
-			}
-		}
-	}()
+func Concat[T any](it ...Iterator[T]) Iterator[T] { return ConcatOpts(it) }
 
-	return &withNext[T]{
-		next: func() (T, bool) {
-			v, ok := q.Pop()
-			return v, ok
+// ConcatOpts is Concat() with options
+func ConcatOpts[T any](it []Iterator[T], opts ...Option) Iterator[T] {
+	st := newStage("concat")
+	i := 0
+
+	return prefetch(&withNext[T]{
+		stage: st,
+		next: func() (r T, ok bool) {
+			start := st.start()
+			for ; i < len(it); i++ {
+				if v, ok := it[i].Next(); ok {
+					st.element(start)
+					return v, true
+				}
+			}
+			return r, false
 		},
-	}
+		close: func() { closeAll(it) },
+		hint: func() bounds {
+			b := exactSize(0)
+			for _, it := range it[i:] {
+				b = b.add(sizeOf(it))
+			}
+			return b
+		},
+		parents: ups(it),
+	}, newOptions(opts...))
 }
@@ -3,8 +3,13 @@ package iter
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
+	"math"
 	"os"
+	"runtime"
+	"strconv"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -165,6 +170,15 @@ func TestSort(t *testing.T) {
 	s := []int{3, 2, 4, 1, 2, 5}
 	require.Equal(t, Sorted(S(s)).Slice(), SortedFunc(S(s), Asending[int]).Slice())
 	require.Equal(t, Reverse(Sorted(S(s))).Slice(), SortedFunc(S(s), Descending[int]).Slice())
+
+	require.Equal(t, -1, Asending(math.MinInt, math.MaxInt))
+	require.Equal(t, 1, Descending(math.MinInt, math.MaxInt))
+	require.Equal(t, []float64{-0.5, 0, 0.5}, SortedFunc(Of(0.5, 0, -0.5), Asending[float64]).Slice())
+}
+
+func TestOddEven(t *testing.T) {
+	require.Equal(t, []int{-3, -1, 1, 3}, Range(-3, 4).Filter(Odd[int]).Slice())
+	require.Equal(t, []int{-2, 0, 2}, Range(-3, 4).Filter(Even[int]).Slice())
 }
 
 func TestConcat(t *testing.T) {
@@ -205,3 +219,162 @@ func TestEachIdx(t *testing.T) {
 	S(want).EachIdx(func(i int, x string) { got = append(got, i) })
 	require.Equal(t, []int{0, 1, 2, 3, 4}, got)
 }
+
+// counter count how many elements are pulled from it
+func counter[T any](it Iterator[T], n *int) Iterator[T] {
+	return &withNext[T]{
+		next: func() (T, bool) {
+			v, ok := it.Next()
+			if ok {
+				*n++
+			}
+			return v, ok
+		},
+	}
+}
+
+func TestTakeWhileLazy(t *testing.T) {
+	pulled := 0
+	it := counter(Of(1, 2, 3, 4, 5, 6, 7), &pulled).TakeWhile(func(x int) bool { return x < 3 })
+	require.Equal(t, 0, pulled)
+
+	require.Equal(t, []int{1, 2}, it.Slice())
+	require.Equal(t, 3, pulled, "pulled one element past the boundary")
+
+	_, ok := it.Next()
+	require.False(t, ok)
+	require.Equal(t, 3, pulled)
+}
+
+func TestDropWhileLazy(t *testing.T) {
+	pulled := 0
+	it := counter(Of(1, 2, 3, 4, 5), &pulled).DropWhile(func(x int) bool { return x < 3 })
+	require.Equal(t, 0, pulled)
+
+	v, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	require.Equal(t, 3, pulled)
+}
+
+func TestConcatLazy(t *testing.T) {
+	first, second := 0, 0
+	it := Concat(counter(Of(1, 2), &first), counter(Of(3, 4), &second))
+	require.Equal(t, 0, first+second)
+
+	it.Next()
+	it.Next()
+	require.Equal(t, 2, first)
+	require.Equal(t, 0, second)
+
+	v, _ := it.Next()
+	require.Equal(t, 3, v)
+	require.Equal(t, 1, second)
+}
+
+func BenchmarkPullOperators(b *testing.B) {
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+	lt := func(x int) bool { return x < 900 }
+
+	benchmarks := [...]struct {
+		name string
+		fn   func(opts ...Option) Iterator[int]
+	}{
+		{"takeWhile", func(opts ...Option) Iterator[int] { return TakeWhile(S(s), lt, opts...) }},
+		{"dropWhile", func(opts ...Option) Iterator[int] { return DropWhile(S(s), lt, opts...) }},
+		{"concat", func(opts ...Option) Iterator[int] { return ConcatOpts([]Iterator[int]{S(s), S(s)}, opts...) }},
+	}
+	for _, bb := range benchmarks {
+		b.Run(bb.name+"/sync", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice(bb.fn())
+			}
+		})
+		b.Run(bb.name+"/prefetch", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				slice(bb.fn(WithBuffer(runtime.NumCPU())))
+			}
+		})
+	}
+}
+
+func TestLazy(t *testing.T) {
+	tests := [...]struct {
+		name string
+		op   func(Iterator[int]) Iterator[int]
+	}{
+		{"map", func(it Iterator[int]) Iterator[int] { return it.Map(Multiply(2)) }},
+		{"filter", func(it Iterator[int]) Iterator[int] { return it.Filter(Even[int]) }},
+		{"skip", func(it Iterator[int]) Iterator[int] { return it.Skip(2) }},
+		{"sorted", func(it Iterator[int]) Iterator[int] { return Sorted(it) }},
+		{"sortedFunc", func(it Iterator[int]) Iterator[int] { return SortedFunc(it, Descending[int]) }},
+		{"reverse", func(it Iterator[int]) Iterator[int] { return Reverse(it) }},
+		{"prefetch", func(it Iterator[int]) Iterator[int] { return TakeWhile(it, Odd[int], WithBuffer(1)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pulled atomic.Int32
+			src := &withNext[int]{
+				next: func() func() (int, bool) {
+					it := Of(3, 1, 2)
+					return func() (int, bool) {
+						pulled.Add(1)
+						return it.Next()
+					}
+				}(),
+			}
+
+			before := runtime.NumGoroutine()
+			it := tt.op(src)
+			require.LessOrEqual(t, runtime.NumGoroutine(), before, "no goroutine should start before Next()")
+			require.Equal(t, int32(0), pulled.Load())
+
+			it.Next()
+			require.NotZero(t, pulled.Load())
+		})
+	}
+}
+
+func TestFusion(t *testing.T) {
+	log := []string{}
+	logger := func(name string, ok func(int) bool) func(int) bool {
+		return func(x int) bool {
+			log = append(log, fmt.Sprintf("%s(%d)", name, x))
+			return ok(x)
+		}
+	}
+	always := func(int) bool { return true }
+
+	it := Map(Of(1, 2, 3, 4).Filter(logger("a", always)).Filter(logger("b", Even[int])), func(x int) int {
+		log = append(log, fmt.Sprintf("m(%d)", x))
+		return x * 10
+	}, WithSequential())
+
+	stages := Describe(it).Stages
+	require.Len(t, stages, 2, "chain should be fused into a single stage over the source")
+	require.Equal(t, "filter+filter+map", stages[len(stages)-1].Name)
+	require.Empty(t, log)
+
+	v, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, 20, v)
+	require.Equal(t, []string{"a(1)", "b(1)", "a(2)", "b(2)", "m(2)"}, log)
+
+	require.Equal(t, []int{40}, it.Slice())
+	require.Equal(t, []string{"a(1)", "b(1)", "a(2)", "b(2)", "m(2)", "a(3)", "b(3)", "a(4)", "b(4)", "m(4)"}, log)
+}
+
+func TestSequentialMap(t *testing.T) {
+	got := Map(Of(1, 2, 3), strconv.Itoa, WithSequential()).Slice()
+	require.Equal(t, []string{"1", "2", "3"}, got)
+}
+
+func TestReverseEmpty(t *testing.T) {
+	_, ok := Reverse(Of[int]()).Next()
+	require.False(t, ok)
+}
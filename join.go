@@ -0,0 +1,202 @@
+package iter
+
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+// buildHash build hash table of the build side of joins
+func buildHash[K comparable, R any](right Iterator[R], key func(R) K) map[K][]R {
+	table := map[K][]R{}
+	for v, ok := right.Next(); ok; v, ok = right.Next() {
+		k := key(v)
+		table[k] = append(table[k], v)
+	}
+	return table
+}
+
+// buildMap return lookup of m, map wrapped with M() is used as it is
+func buildMap[K comparable, V any](m MapIterator[K, V]) map[K]V {
+	if mi, ok := m.(*mapIter[K, V]); ok {
+		return mi.get()
+	}
+
+	table := map[K]V{}
+	items := m.Items()
+	for item, ok := items.Next(); ok; item, ok = items.Next() {
+		table[item.Key] = item.Value
+	}
+	return table
+}
+
+// probe probe left against lookup built on the first Next() and emit matches in order
+// with outer, left without matches is emitted once with no matches
+func probe[L, R any, K comparable, P any](left Iterator[L], leftKey func(L) K, build func() func(K) []R, outer bool, emit func(L, []R, int) P) Iterator[P] {
+	var lookup func(K) []R
+	var cur L
+	var matches []R
+	index := 0
+
+	return &withNext[P]{
+		next: func() (r P, ok bool) {
+			if lookup == nil {
+				lookup = build()
+			}
+
+			for index >= len(matches) {
+				l, ok := left.Next()
+				if !ok {
+					return r, false
+				}
+
+				cur, matches, index = l, lookup(leftKey(l)), 0
+				if len(matches) == 0 && outer {
+					return emit(cur, nil, 0), true
+				}
+			}
+
+			index++
+			return emit(cur, matches, index-1), true
+		},
+		close: func() { Close(left) },
+	}
+}
+
+func pairOf[L, R any](l L, matches []R, i int) Pair[L, R] { return Pair[L, R]{l, matches[i]} }
+
+func pairOfPtr[L, R any](l L, matches []R, i int) Pair[L, *R] {
+	if len(matches) == 0 {
+		return Pair[L, *R]{Left: l}
+	}
+	return Pair[L, *R]{l, &matches[i]}
+}
+
+// HashJoin emit pairs of left and right with equal keys, right is the build side loaded into memory
+func HashJoin[L, R any, K comparable](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K) Iterator[Pair[L, R]] {
+	return describe(probe(left, leftKey, hashLookup(right, rightKey), false, pairOf[L, R]), "hashJoin", left, right)
+}
+
+// LeftJoin is HashJoin() which also emits left without matches, with nil Right
+func LeftJoin[L, R any, K comparable](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K) Iterator[Pair[L, *R]] {
+	return describe(probe(left, leftKey, hashLookup(right, rightKey), true, pairOfPtr[L, R]), "leftJoin", left, right)
+}
+
+// HashJoinMap is HashJoin() with map as the build side
+func HashJoinMap[L any, K comparable, V any](left Iterator[L], leftKey func(L) K, m MapIterator[K, V]) Iterator[Pair[L, V]] {
+	return describe(probe(left, leftKey, mapLookup(m), false, pairOf[L, V]), "hashJoin", left, m)
+}
+
+// LeftJoinMap is LeftJoin() with map as the build side
+func LeftJoinMap[L any, K comparable, V any](left Iterator[L], leftKey func(L) K, m MapIterator[K, V]) Iterator[Pair[L, *V]] {
+	return describe(probe(left, leftKey, mapLookup(m), true, pairOfPtr[L, V]), "leftJoin", left, m)
+}
+
+func hashLookup[K comparable, R any](right Iterator[R], rightKey func(R) K) func() func(K) []R {
+	return func() func(K) []R {
+		table := buildHash(right, rightKey)
+		return func(k K) []R { return table[k] }
+	}
+}
+
+func mapLookup[K comparable, V any](m MapIterator[K, V]) func() func(K) []V {
+	return func() func(K) []V {
+		table := buildMap(m)
+		return func(k K) []V {
+			if v, ok := table[k]; ok {
+				return []V{v}
+			}
+			return nil
+		}
+	}
+}
+
+// SemiJoin emit left which has a match in right
+func SemiJoin[L, R any, K comparable](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K) Iterator[L] {
+	return describe(semiJoin(left, leftKey, hashContains(right, rightKey), true), "semiJoin", left, right)
+}
+
+// AntiJoin emit left which has no match in right
+func AntiJoin[L, R any, K comparable](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K) Iterator[L] {
+	return describe(semiJoin(left, leftKey, hashContains(right, rightKey), false), "antiJoin", left, right)
+}
+
+// SemiJoinMap is SemiJoin() with map as the build side
+func SemiJoinMap[L any, K comparable, V any](left Iterator[L], leftKey func(L) K, m MapIterator[K, V]) Iterator[L] {
+	return describe(semiJoin(left, leftKey, mapContains(m), true), "semiJoin", left, m)
+}
+
+// AntiJoinMap is AntiJoin() with map as the build side
+func AntiJoinMap[L any, K comparable, V any](left Iterator[L], leftKey func(L) K, m MapIterator[K, V]) Iterator[L] {
+	return describe(semiJoin(left, leftKey, mapContains(m), false), "antiJoin", left, m)
+}
+
+func hashContains[K comparable, R any](right Iterator[R], rightKey func(R) K) func() func(K) bool {
+	return func() func(K) bool {
+		table := buildHash(right, rightKey)
+		return func(k K) bool { _, ok := table[k]; return ok }
+	}
+}
+
+func mapContains[K comparable, V any](m MapIterator[K, V]) func() func(K) bool {
+	return func() func(K) bool {
+		table := buildMap(m)
+		return func(k K) bool { _, ok := table[k]; return ok }
+	}
+}
+
+func semiJoin[L any, K comparable](left Iterator[L], leftKey func(L) K, build func() func(K) bool, want bool) Iterator[L] {
+	return lazy(func() Iterator[L] {
+		contains := build()
+		return filter(left, func(l L) bool { return contains(leftKey(l)) == want })
+	})
+}
+
+// SortMergeJoin emit pairs of left and right with equal keys, both must be sorted by cmp
+// only right elements of the current key are kept in memory
+func SortMergeJoin[L, R, K any](left Iterator[L], right Iterator[R], leftKey func(L) K, rightKey func(R) K, cmp Less[K]) Iterator[Pair[L, R]] {
+	rp := Peekable(right)
+	var cur L
+	var curKey, groupKey K
+	var group []R
+	hasGroup := false
+	index := 0
+
+	return &withNext[Pair[L, R]]{
+		name:    "sortMergeJoin",
+		parents: []any{left, right},
+		next: func() (r Pair[L, R], ok bool) {
+			for {
+				if hasGroup && index < len(group) {
+					index++
+					return Pair[L, R]{cur, group[index-1]}, true
+				}
+
+				l, ok := left.Next()
+				if !ok {
+					return r, false
+				}
+				cur, curKey, index = l, leftKey(l), 0
+
+				if hasGroup && cmp(curKey, groupKey) == 0 {
+					continue
+				}
+
+				group, groupKey, hasGroup = group[:0], curKey, true
+				for v, ok := rp.Peek(); ok; v, ok = rp.Peek() {
+					c := cmp(rightKey(v), curKey)
+					if c > 0 {
+						break
+					}
+					rp.Next()
+					if c == 0 {
+						group = append(group, v)
+					}
+				}
+			}
+		},
+		close: func() {
+			Close(left)
+			Close[R](rp)
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type order struct {
+	UserID int
+	Item   string
+}
+
+var (
+	testUsers  = []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	testOrders = []order{{1, "apple"}, {3, "cherry"}, {1, "avocado"}, {4, "durian"}}
+)
+
+func userID(u user) int     { return u.ID }
+func orderUser(o order) int { return o.UserID }
+
+func TestHashJoin(t *testing.T) {
+	got := HashJoin(S(testUsers), S(testOrders), userID, orderUser).Slice()
+	require.Equal(t, []Pair[user, order]{
+		{testUsers[0], testOrders[0]},
+		{testUsers[0], testOrders[2]},
+		{testUsers[2], testOrders[1]},
+	}, got)
+}
+
+func TestLeftJoin(t *testing.T) {
+	got := []string{}
+	LeftJoin(S(testUsers), S(testOrders), userID, orderUser).Each(func(p Pair[user, *order]) {
+		item := "-"
+		if p.Right != nil {
+			item = p.Right.Item
+		}
+		got = append(got, p.Left.Name+":"+item)
+	})
+	require.Equal(t, []string{"alice:apple", "alice:avocado", "bob:-", "carol:cherry"}, got)
+}
+
+func TestHashJoinMap(t *testing.T) {
+	names := M(map[int]string{1: "alice", 2: "bob"})
+
+	got := HashJoinMap(S(testOrders), orderUser, names).Slice()
+	require.Equal(t, []Pair[order, string]{{testOrders[0], "alice"}, {testOrders[2], "alice"}}, got)
+
+	left := LeftJoinMap(S(testOrders), orderUser, names).Slice()
+	require.Len(t, left, 4)
+	require.Equal(t, "alice", *left[0].Right)
+	require.Nil(t, left[1].Right)
+}
+
+func TestSemiJoin(t *testing.T) {
+	require.Equal(t, []user{testUsers[0], testUsers[2]}, SemiJoin(S(testUsers), S(testOrders), userID, orderUser).Slice())
+	require.Equal(t, []user{testUsers[1]}, AntiJoin(S(testUsers), S(testOrders), userID, orderUser).Slice())
+
+	m := M(map[int]bool{2: true})
+	require.Equal(t, []user{testUsers[1]}, SemiJoinMap(S(testUsers), userID, m).Slice())
+	require.Equal(t, []user{testUsers[0], testUsers[2]}, AntiJoinMap(S(testUsers), userID, m).Slice())
+}
+
+func TestSortMergeJoin(t *testing.T) {
+	type args struct {
+		left  []int
+		right []string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []string
+	}{
+		{`valid`, args{[]int{1, 2, 4}, []string{"1a", "2a", "2b", "3a", "4a"}}, []string{"1:1a", "2:2a", "2:2b", "4:4a"}},
+		{`duplicated left`, args{[]int{2, 2, 3}, []string{"1a", "2a", "2b"}}, []string{"2:2a", "2:2b", "2:2a", "2:2b"}},
+		{`no match`, args{[]int{1, 3}, []string{"2a", "4a"}}, []string{}},
+		{`empty right`, args{[]int{1, 3}, nil}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := func(s string) int { return int(s[0] - '0') }
+			got := []string{}
+			SortMergeJoin(S(tt.args.left), S(tt.args.right), func(x int) int { return x }, key, Asending[int]).Each(func(p Pair[int, string]) {
+				got = append(got, strings.Join([]string{string(rune('0' + p.Left)), p.Right}, ":"))
+			})
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package iter
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// MapKeyed map elements on shards goroutines, elements of the same key are mapped by the same goroutine in input order
+// results are emitted as they are ready keeping order of each key, WithOrdered() emit them in input order
+// shards <= 0 means runtime.GOMAXPROCS(), WithBuffer() set buffer size of each shard
+func MapKeyed[T1, T2 any, K comparable](it Iterator[T1], key func(T1) K, shards int, mapper func(T1) T2, opts ...Option) Iterator[T2] {
+	o := newOptions(opts...)
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		v   T1
+		res chan T2 // result of ordered mode
+	}
+
+	st := newStage("mapKeyed")
+	hint, started, pulled := countdown(it)
+	ctx, cancel := context.WithCancel(context.Background())
+	var out chan T2
+	var q *Queue[chan T2]
+
+	run := func() {
+		started()
+		out = make(chan T2)
+		if o.ordered {
+			q = newQueue[chan T2](o)
+			q.stage = st
+		}
+
+		inbox := make([]chan job, shards)
+		var wg sync.WaitGroup
+		for i := range inbox {
+			inbox[i] = make(chan job, o.buffer)
+			wg.Add(1)
+			done := st.goroutine()
+			go func(inbox <-chan job) {
+				defer wg.Done()
+				defer done()
+				for j := range inbox {
+					start := st.start()
+					r := mapper(j.v)
+					st.element(start)
+
+					if j.res != nil {
+						j.res <- r
+						continue
+					}
+					select {
+					case out <- r:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(inbox[i])
+		}
+
+		done := st.goroutine()
+		go func() {
+			defer done()
+			defer func() {
+				for _, ch := range inbox {
+					close(ch)
+				}
+				if q != nil {
+					q.Close()
+				}
+				wg.Wait()
+				close(out)
+			}()
+
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				j := job{v: v}
+				if q != nil {
+					j.res = make(chan T2, 1) // buffered not to block the shard
+					if err := q.PushCtx(ctx, j.res); err != nil {
+						return
+					}
+				}
+
+				select {
+				case inbox[hashKey(key(v))%uint64(shards)] <- j:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	return &withNext[T2]{
+		stage: st,
+		next: func() (r T2, ok bool) {
+			if out == nil {
+				run()
+			}
+
+			if ctx.Err() != nil {
+				return r, false
+			}
+
+			if q != nil {
+				ch, ok := q.Pop()
+				if !ok {
+					cancel()
+					return r, false
+				}
+				pulled()
+				return <-ch, true
+			}
+
+			select {
+			case v, ok := <-out:
+				if !ok {
+					cancel()
+					return r, false
+				}
+				pulled()
+				return v, true
+			case <-ctx.Done():
+				return r, false
+			}
+		},
+		close: func() {
+			cancel()
+			if q != nil {
+				q.Close()
+			}
+			Close(it)
+		},
+		hint:    hint,
+		parents: []any{it},
+	}
+}
+
+// ShardBy call fn on shards goroutines and wait until it is drained, elements of the same key are processed by the same goroutine in input order
+func ShardBy[T any, K comparable](it Iterator[T], key func(T) K, shards int, fn func(T), opts ...Option) {
+	results := MapKeyed(it, key, shards, func(v T) struct{} {
+		fn(v)
+		return struct{}{}
+	}, opts...)
+	for _, ok := results.Next(); ok; _, ok = results.Next() {
+	}
+}
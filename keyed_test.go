@@ -0,0 +1,146 @@
+package iter
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+type event struct {
+	user int
+	seq  int
+}
+
+func events(users, perUser int) []event {
+	var r []event
+	for seq := 0; seq < perUser; seq++ {
+		for user := 0; user < users; user++ {
+			r = append(r, event{user, seq})
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(r), func(i, j int) {
+		// shuffle users within each round to keep order of each user
+		if r[i].user != r[j].user && r[i].seq == r[j].seq {
+			r[i], r[j] = r[j], r[i]
+		}
+	})
+	return r
+}
+
+func TestMapKeyedPerKeyOrder(t *testing.T) {
+	input := events(50, 200)
+
+	type args struct {
+		shards int
+		opts   []Option
+	}
+	tests := [...]struct {
+		name string
+		args args
+	}{
+		{"unordered", args{8, nil}},
+		{"unordered buffered", args{8, []Option{WithBuffer(16)}}},
+		{"ordered", args{8, []Option{WithOrdered()}}},
+		{"single shard", args{1, nil}},
+		{"default shards", args{0, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var active sync.Map
+			var overlapped atomic.Bool
+
+			got := MapKeyed(S(input), func(e event) int { return e.user }, tt.args.shards, func(e event) event {
+				n, _ := active.LoadOrStore(e.user, new(atomic.Int32))
+				if n.(*atomic.Int32).Add(1) > 1 {
+					overlapped.Store(true)
+				}
+				if e.seq%7 == 0 {
+					runtime.Gosched()
+				}
+				n.(*atomic.Int32).Add(-1)
+				return e
+			}, tt.args.opts...).Slice()
+
+			require.False(t, overlapped.Load(), "elements of a key must not be mapped concurrently")
+			require.Len(t, got, len(input))
+
+			next := map[int]int{}
+			for _, e := range got {
+				require.Equal(t, next[e.user], e.seq, "user %d", e.user)
+				next[e.user]++
+			}
+		})
+	}
+}
+
+func TestMapKeyedOrdered(t *testing.T) {
+	got := MapKeyed(Range(0, 1000), func(x int) int { return x % 13 }, 4, func(x int) int {
+		if x%5 == 0 {
+			time.Sleep(time.Microsecond)
+		}
+		return x * 2
+	}, WithOrdered()).Slice()
+	require.Equal(t, Range(0, 2000, 2).Slice(), got)
+}
+
+func TestMapKeyedParallel(t *testing.T) {
+	const shards = 4
+
+	// the first keys go to different shards
+	input := []int{0}
+	for x := 1; len(input) < shards; x++ {
+		if !slices.ContainsFunc(input, func(y int) bool { return hashKey(x)%shards == hashKey(y)%shards }) {
+			input = append(input, x)
+		}
+	}
+	input = append(input, Range(100, 164).Slice()...)
+
+	probe := newConcurrencyProbe(shards)
+	ShardBy(S(input), func(x int) int { return x }, shards, func(int) {
+		probe.enter()
+		probe.leave()
+	})
+	require.Equal(t, int32(shards), probe.Peak(), "different keys should run in parallel")
+}
+
+func TestShardBy(t *testing.T) {
+	var mu sync.Mutex
+	sums := map[int][]int{}
+	ShardBy(S(events(10, 50)), func(e event) int { return e.user }, 3, func(e event) {
+		mu.Lock()
+		defer mu.Unlock()
+		sums[e.user] = append(sums[e.user], e.seq)
+	})
+
+	require.Len(t, sums, 10)
+	for user, seqs := range sums {
+		require.Equal(t, Range(0, 50).Slice(), seqs, "user %d", user)
+	}
+}
+
+func TestMapKeyedClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for _, opts := range [][]Option{nil, {WithOrdered()}} {
+		it := MapKeyed(Range(0, math.MaxInt), func(x int) int { return x % 7 }, 4, Multiply(2), opts...)
+		require.True(t, Any(it, func(x int) bool { return x > 100 }))
+		waitGoroutines(t, before)
+	}
+}
+
+func TestMapKeyedSizeHint(t *testing.T) {
+	it := MapKeyed(Range(0, 10), func(x int) int { return x }, 2, Multiply(2), WithOrdered())
+	min, max, exact := SizeHint(it)
+	require.Equal(t, []any{10, 10, true}, []any{min, max, exact})
+
+	it.Next()
+	min, max, exact = SizeHint(it)
+	require.Equal(t, []any{9, 9, true}, []any{min, max, exact})
+}
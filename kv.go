@@ -0,0 +1,132 @@
+package iter
+
+// Iterator2 iterate key, value pairs
+// like Iterator it is lazy and Items(), ToMap() and Each() consume it
+type Iterator2[K comparable, V any] interface {
+	Next() (K, V, bool)
+
+	Filter(func(K, V) bool) Iterator2[K, V]
+	Items() Iterator[Item[K, V]]
+	ToMap() map[K]V
+	Each(func(K, V))
+}
+
+type withNext2[K comparable, V any] struct {
+	next  func() (K, V, bool)
+	close func()
+
+	name    string
+	parents []any
+}
+
+func (it *withNext2[K, V]) Next() (K, V, bool)                        { return it.next() }
+func (it *withNext2[K, V]) Filter(fn func(K, V) bool) Iterator2[K, V] { return filter2(it, fn) }
+func (it *withNext2[K, V]) Items() Iterator[Item[K, V]]               { return items2[K, V](it) }
+func (it *withNext2[K, V]) ToMap() map[K]V                            { return toMap[K, V](it) }
+func (it *withNext2[K, V]) Each(fn func(K, V))                        { each2[K, V](it, fn) }
+func (it *withNext2[K, V]) Close() {
+	if it.close != nil {
+		it.close()
+	}
+}
+
+// close2 close it if it is a Closer
+func close2[K comparable, V any](it Iterator2[K, V]) {
+	if c, ok := it.(Closer); ok {
+		c.Close()
+	}
+}
+
+// KV convert iterator of items to Iterator2
+func KV[K comparable, V any](it Iterator[Item[K, V]]) Iterator2[K, V] {
+	return &withNext2[K, V]{
+		name:    "kv",
+		parents: []any{it},
+		next: func() (k K, v V, ok bool) {
+			item, ok := it.Next()
+			return item.Key, item.Value, ok
+		},
+		close: func() { Close(it) },
+	}
+}
+
+func items2[K comparable, V any](it Iterator2[K, V]) Iterator[Item[K, V]] {
+	return &withNext[Item[K, V]]{
+		name:    "items",
+		parents: []any{it},
+		next: func() (Item[K, V], bool) {
+			k, v, ok := it.Next()
+			return Item[K, V]{k, v}, ok
+		},
+		close: func() { close2(it) },
+	}
+}
+
+func filter2[K comparable, V any](it Iterator2[K, V], fn func(K, V) bool) Iterator2[K, V] {
+	return &withNext2[K, V]{
+		name:    "filter",
+		parents: []any{it},
+		next: func() (K, V, bool) {
+			for k, v, ok := it.Next(); ok; k, v, ok = it.Next() {
+				if fn(k, v) {
+					return k, v, true
+				}
+			}
+			var k K
+			var v V
+			return k, v, false
+		},
+		close: func() { close2(it) },
+	}
+}
+
+func toMap[K comparable, V any](it Iterator2[K, V]) map[K]V {
+	m := map[K]V{}
+	each2(it, func(k K, v V) { m[k] = v })
+	return m
+}
+
+func each2[K comparable, V any](it Iterator2[K, V], fn func(K, V)) {
+	for k, v, ok := it.Next(); ok; k, v, ok = it.Next() {
+		fn(k, v)
+	}
+}
+
+// MapKV map each pair with fn
+func MapKV[K1 comparable, V1 any, K2 comparable, V2 any](it Iterator2[K1, V1], fn func(K1, V1) (K2, V2)) Iterator2[K2, V2] {
+	return &withNext2[K2, V2]{
+		name:    "map",
+		parents: []any{it},
+		next: func() (k2 K2, v2 V2, ok bool) {
+			k, v, ok := it.Next()
+			if !ok {
+				return k2, v2, false
+			}
+			k2, v2 = fn(k, v)
+			return k2, v2, true
+		},
+		close: func() { close2(it) },
+	}
+}
+
+// MapKeys map keys with fn, values are kept
+func MapKeys[K1 comparable, V any, K2 comparable](it Iterator2[K1, V], fn func(K1, V) K2) Iterator2[K2, V] {
+	return MapKV(it, func(k K1, v V) (K2, V) { return fn(k, v), v })
+}
+
+// MapValues map values with fn, keys are kept
+func MapValues[K comparable, V1, V2 any](it Iterator2[K, V1], fn func(K, V1) V2) Iterator2[K, V2] {
+	return MapKV(it, func(k K, v V1) (K, V2) { return k, fn(k, v) })
+}
+
+// Swap swap keys and values
+func Swap[K, V comparable](it Iterator2[K, V]) Iterator2[V, K] {
+	return MapKV(it, func(k K, v V) (V, K) { return v, k })
+}
+
+// ReduceKV fold pairs into init with fn
+func ReduceKV[K comparable, V, A any](it Iterator2[K, V], init A, fn func(A, K, V) A) A {
+	acc := init
+	each2(it, func(k K, v V) { acc = fn(acc, k, v) })
+	return acc
+}
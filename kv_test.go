@@ -0,0 +1,96 @@
+package iter
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator2(t *testing.T) {
+	m := map[int]string{1: "one", 2: "two", 3: "three", 4: "four"}
+
+	type args struct {
+		fn func(Iterator2[int, string]) map[int]string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want map[int]string
+	}{
+		{"identity", args{func(it Iterator2[int, string]) map[int]string { return it.ToMap() }}, m},
+		{"filter", args{func(it Iterator2[int, string]) map[int]string {
+			return it.Filter(func(k int, _ string) bool { return Even(k) }).ToMap()
+		}}, map[int]string{2: "two", 4: "four"}},
+		{"map values", args{func(it Iterator2[int, string]) map[int]string {
+			return MapValues(it, func(_ int, v string) string { return strings.ToUpper(v) }).ToMap()
+		}}, map[int]string{1: "ONE", 2: "TWO", 3: "THREE", 4: "FOUR"}},
+		{"map keys", args{func(it Iterator2[int, string]) map[int]string {
+			return MapKeys(it, func(k int, _ string) int { return -k }).ToMap()
+		}}, map[int]string{-1: "one", -2: "two", -3: "three", -4: "four"}},
+		{"chain", args{func(it Iterator2[int, string]) map[int]string {
+			return MapKV(it.Filter(func(_ int, v string) bool { return len(v) == 3 }),
+				func(k int, v string) (int, string) { return k * 10, v + v }).
+				Filter(func(k int, _ string) bool { return k > 10 }).ToMap()
+		}}, map[int]string{20: "twotwo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.args.fn(M(m).KV()))
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	got := Swap(M(map[int]string{1: "one", 2: "two"}).KV()).ToMap()
+	require.Equal(t, map[string]int{"one": 1, "two": 2}, got)
+}
+
+func TestReduceKV(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	require.Equal(t, 6, ReduceKV(M(m).KV(), 0, func(acc int, _ string, v int) int { return acc + v }))
+	require.Equal(t, "x", ReduceKV(KV(Of[Item[string, int]]()), "x", func(acc string, k string, _ int) string { return acc + k }))
+}
+
+func TestKVConversion(t *testing.T) {
+	items := []Item[int, string]{{1, "a"}, {2, "b"}, {3, "c"}}
+
+	got := KV(S(items)).Items().Slice()
+	require.Equal(t, items, got)
+
+	var keys []string
+	KV(S(items)).Each(func(k int, v string) { keys = append(keys, strconv.Itoa(k)+v) })
+	require.Equal(t, []string{"1a", "2b", "3c"}, keys)
+
+	back := M(KV(S(items)).ToMap())
+	require.Equal(t, []int{1, 2, 3}, Sorted(back.Keys()).Slice())
+}
+
+func TestKVLazy(t *testing.T) {
+	pulled := 0
+	it := MapValues(KV(counter(S([]Item[int, int]{{1, 1}, {2, 2}}), &pulled)), func(k, v int) int { return k + v })
+	require.Equal(t, 0, pulled)
+
+	k, v, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, 1, k)
+	require.Equal(t, 2, v)
+	require.Equal(t, 1, pulled)
+}
+
+func TestKVClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	m := map[int]int{}
+	for i := 0; i < 100; i++ {
+		m[i] = i
+	}
+	it := Swap(M(m).KV().Filter(func(k, _ int) bool { return true }))
+	_, _, ok := it.Next()
+	require.True(t, ok)
+
+	it.(Closer).Close()
+	waitGoroutines(t, before)
+}
@@ -1,6 +1,8 @@
 package iter
 
 import (
+	"context"
+
 	"golang.org/x/exp/maps"
 )
 
@@ -8,6 +10,8 @@ type MapIterator[K comparable, V any] interface {
 	Keys() Iterator[K]
 	Values() Iterator[V]
 	Items() Iterator[Item[K, V]]
+	KV() Iterator2[K, V]
+	ToMap() map[K]V
 
 	Each(func(K, V))
 }
@@ -18,39 +22,94 @@ type Item[K comparable, V any] struct {
 }
 
 type mapIter[K comparable, V any] struct {
-	orig map[K]V
+	orig  map[K]V
+	build func() map[K]V
+	opts  []Option
+
+	name    string
+	parents []any
 }
 
-func M[K comparable, V any](m map[K]V) MapIterator[K, V] {
+// M wrap map, WithBuffer() set buffer size of Items()
+func M[K comparable, V any](m map[K]V, opts ...Option) MapIterator[K, V] {
 	return &mapIter[K, V]{
 		orig: m,
+		opts: opts,
 	}
 }
 
-func (m *mapIter[K, V]) Keys() Iterator[K]            { return keys(m.orig) }
-func keys[K comparable, V any](m map[K]V) Iterator[K] { return S(maps.Keys(m)) }
+// lazyM return MapIterator of map built on the first use
+func lazyM[K comparable, V any](build func() map[K]V, opts ...Option) MapIterator[K, V] {
+	return &mapIter[K, V]{build: build, opts: opts}
+}
+
+// get return the wrapped map, building it if required
+func (m *mapIter[K, V]) get() map[K]V {
+	if m.build != nil {
+		m.orig, m.build = m.build(), nil
+	}
+	return m.orig
+}
 
-func (m *mapIter[K, V]) Values() Iterator[V]            { return values(m.orig) }
-func values[K comparable, V any](m map[K]V) Iterator[V] { return S(maps.Values(m)) }
+func (m *mapIter[K, V]) Keys() Iterator[K] { return describe(keys(m.get), "keys", m) }
+func keys[K comparable, V any](m func() map[K]V) Iterator[K] {
+	return lazyHint(func() Iterator[K] { return S(maps.Keys(m())) }, func() bounds { return exactSize(len(m())) })
+}
 
-func (m *mapIter[K, V]) Items() Iterator[Item[K, V]] { return items(m.orig) }
-func items[K comparable, V any](m map[K]V) Iterator[Item[K, V]] {
-	q := newQueue[Item[K, V]]()
-	go func() {
-		defer q.Close()
-		for k, v := range m {
-			q.Push(Item[K, V]{k, v})
-		}
-	}()
+func (m *mapIter[K, V]) Values() Iterator[V] { return describe(values(m.get), "values", m) }
+func values[K comparable, V any](m func() map[K]V) Iterator[V] {
+	return lazyHint(func() Iterator[V] { return S(maps.Values(m())) }, func() bounds { return exactSize(len(m())) })
+}
+
+func (m *mapIter[K, V]) Items() Iterator[Item[K, V]] {
+	return describe(items(m.get, m.opts...), "items", m)
+}
+func items[K comparable, V any](get func() map[K]V, opts ...Option) Iterator[Item[K, V]] {
+	var q *Queue[Item[K, V]]
+	size, popped := 0, 0
 	return &withNext[Item[K, V]]{
 		next: func() (Item[K, V], bool) {
+			if q == nil {
+				m := get()
+				size = len(m)
+				q = newQueue[Item[K, V]](newOptions(opts...))
+				go func() {
+					defer q.Close()
+					for k, v := range m {
+						if err := q.PushCtx(context.Background(), Item[K, V]{k, v}); err != nil {
+							return
+						}
+					}
+				}()
+			}
+
 			item, ok := q.Pop()
+			if ok {
+				popped++
+			}
 
 			return item, ok
 		},
+		close: func() {
+			if q != nil {
+				q.Close()
+			}
+		},
+		hint: func() bounds {
+			if q == nil {
+				return exactSize(len(get()))
+			}
+			return exactSize(size - popped)
+		},
 	}
 }
 
+// ToMap return copy of the map
+func (m *mapIter[K, V]) ToMap() map[K]V { return maps.Clone(m.get()) }
+
+// KV iterate map as Iterator2, convert back with M(it.ToMap())
+func (m *mapIter[K, V]) KV() Iterator2[K, V] { return KV(m.Items()) }
+
 func (m *mapIter[K, V]) Each(each func(K, V)) { mapEach[K, V](m, each) }
 func mapEach[K comparable, V any](m MapIterator[K, V], each func(K, V)) {
 	fanOut(m.Items(), func(item Item[K, V]) { each(item.Key, item.Value) })
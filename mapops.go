@@ -0,0 +1,153 @@
+package iter
+
+// MergeMaps merge maps in order on the first use, resolve choose value of a key in several maps
+// a is the value merged so far and b is the value of the later map, nil resolve keeps the later one
+func MergeMaps[K comparable, V any](resolve func(k K, a, b V) V, ms ...MapIterator[K, V]) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		r := map[K]V{}
+		for _, m := range ms {
+			for k, v := range buildMap(m) {
+				if old, ok := r[k]; ok && resolve != nil {
+					v = resolve(k, old, v)
+				}
+				r[k] = v
+			}
+		}
+		return r
+	}), "mergeMaps", ups(ms)...)
+}
+
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota + 1
+	Removed
+	Changed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// MapChange is a change of an entry, Old is zero for Added and New is zero for Removed
+type MapChange[K comparable, V any] struct {
+	Kind ChangeKind
+	Key  K
+	Old  V
+	New  V
+}
+
+// DiffMaps report changes from old to new, see DiffMapsFunc()
+func DiffMaps[K, V comparable](old, new MapIterator[K, V]) Iterator[MapChange[K, V]] {
+	return DiffMapsFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffMapsFunc report added and changed entries of new then removed entries of old, eq compare values
+// both maps are loaded on the first Next()
+func DiffMapsFunc[K comparable, V any](old, new MapIterator[K, V], eq func(a, b V) bool) Iterator[MapChange[K, V]] {
+	return describe(lazy(func() Iterator[MapChange[K, V]] {
+		o, n := buildMap(old), buildMap(new)
+
+		var changes []MapChange[K, V]
+		for k, v := range n {
+			ov, ok := o[k]
+			switch {
+			case !ok:
+				changes = append(changes, MapChange[K, V]{Kind: Added, Key: k, New: v})
+			case !eq(ov, v):
+				changes = append(changes, MapChange[K, V]{Kind: Changed, Key: k, Old: ov, New: v})
+			}
+		}
+		for k, v := range o {
+			if _, ok := n[k]; !ok {
+				changes = append(changes, MapChange[K, V]{Kind: Removed, Key: k, Old: v})
+			}
+		}
+		return S(changes)
+	}), "diffMaps", old, new)
+}
+
+// Invert swap keys and values, resolve choose key when several keys have the same value
+// a is the key kept so far and b is the other one, nil resolve keeps any of them
+func Invert[K, V comparable](m MapIterator[K, V], resolve func(v V, a, b K) K) MapIterator[V, K] {
+	return describeM(lazyM(func() map[V]K {
+		r := map[V]K{}
+		for k, v := range buildMap(m) {
+			if old, ok := r[v]; ok && resolve != nil {
+				k = resolve(v, old, k)
+			}
+			r[v] = k
+		}
+		return r
+	}), "invert", m)
+}
+
+// InvertAll swap keys and values, keeping all keys of the same value
+func InvertAll[K, V comparable](m MapIterator[K, V]) MapIterator[V, []K] {
+	return describeM(lazyM(func() map[V][]K {
+		r := map[V][]K{}
+		for k, v := range buildMap(m) {
+			r[v] = append(r[v], k)
+		}
+		return r
+	}), "invertAll", m)
+}
+
+// FilterEntries keep entries which satisfy pred
+func FilterEntries[K comparable, V any](m MapIterator[K, V], pred func(K, V) bool) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		r := map[K]V{}
+		for k, v := range buildMap(m) {
+			if pred(k, v) {
+				r[k] = v
+			}
+		}
+		return r
+	}), "filter", m)
+}
+
+// FilterKeys keep entries whose key satisfies pred
+func FilterKeys[K comparable, V any](m MapIterator[K, V], pred func(K) bool) MapIterator[K, V] {
+	return FilterEntries(m, func(k K, _ V) bool { return pred(k) })
+}
+
+// FilterValues keep entries whose value satisfies pred
+func FilterValues[K comparable, V any](m MapIterator[K, V], pred func(V) bool) MapIterator[K, V] {
+	return FilterEntries(m, func(_ K, v V) bool { return pred(v) })
+}
+
+// PickKeys keep entries of keys
+func PickKeys[K comparable, V any](m MapIterator[K, V], keys ...K) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		src := buildMap(m)
+		r := make(map[K]V, len(keys))
+		for _, k := range keys {
+			if v, ok := src[k]; ok {
+				r[k] = v
+			}
+		}
+		return r
+	}), "pickKeys", m)
+}
+
+// OmitKeys drop entries of keys
+func OmitKeys[K comparable, V any](m MapIterator[K, V], keys ...K) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		omit := toSet(S(keys))
+		r := map[K]V{}
+		for k, v := range buildMap(m) {
+			if _, ok := omit[k]; !ok {
+				r[k] = v
+			}
+		}
+		return r
+	}), "omitKeys", m)
+}
@@ -0,0 +1,117 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+func TestMergeMaps(t *testing.T) {
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 20, "z": 30}
+	c := map[string]int{"z": 300}
+
+	type args struct {
+		resolve func(k string, a, b int) int
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want map[string]int
+	}{
+		{"later wins", args{nil}, map[string]int{"x": 1, "y": 20, "z": 300}},
+		{"first wins", args{func(_ string, a, _ int) int { return a }}, map[string]int{"x": 1, "y": 2, "z": 30}},
+		{"sum", args{func(_ string, a, b int) int { return a + b }}, map[string]int{"x": 1, "y": 22, "z": 330}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeMaps(tt.args.resolve, M(a), M(b), M(c)).ToMap()
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.Equal(t, map[string]int{}, MergeMaps[string, int](nil).ToMap())
+}
+
+func TestMergeMapsLazy(t *testing.T) {
+	a := map[string]int{"x": 1}
+	merged := MergeMaps(nil, M(a))
+	a["y"] = 2
+	require.Equal(t, []string{"x", "y"}, Sorted(merged.Keys()).Slice())
+
+	a["z"] = 3
+	require.Equal(t, map[string]int{"x": 1, "y": 2}, merged.ToMap(), "built once")
+}
+
+func TestDiffMaps(t *testing.T) {
+	old := map[string]string{"host": "a", "port": "80", "debug": "true"}
+	new := map[string]string{"host": "b", "port": "80", "tls": "on"}
+
+	got := DiffMaps(M(old), M(new)).Slice()
+	slices.SortFunc(got, func(a, b MapChange[string, string]) int { return strings.Compare(a.Key, b.Key) })
+	require.Equal(t, []MapChange[string, string]{
+		{Kind: Removed, Key: "debug", Old: "true"},
+		{Kind: Changed, Key: "host", Old: "a", New: "b"},
+		{Kind: Added, Key: "tls", New: "on"},
+	}, got)
+
+	require.Empty(t, DiffMaps(M(old), M(old)).Slice())
+	require.Equal(t, "changed", Changed.String())
+
+	folded := DiffMapsFunc(M(map[int]string{1: "A"}), M(map[int]string{1: "a"}), strings.EqualFold).Slice()
+	require.Empty(t, folded)
+}
+
+func TestInvert(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 1}
+
+	got := Invert(M(m), func(_ int, a, b string) string {
+		if a < b {
+			return a
+		}
+		return b
+	}).ToMap()
+	require.Equal(t, map[int]string{1: "a", 2: "b"}, got)
+
+	all := InvertAll(M(m)).ToMap()
+	slices.Sort(all[1])
+	require.Equal(t, map[int][]string{1: {"a", "c"}, 2: {"b"}}, all)
+}
+
+func TestFilterKeys(t *testing.T) {
+	m := map[int]string{1: "one", 2: "two", 3: "three", 4: "four"}
+
+	type args struct {
+		fn func(MapIterator[int, string]) MapIterator[int, string]
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want map[int]string
+	}{
+		{"keys", args{func(m MapIterator[int, string]) MapIterator[int, string] { return FilterKeys(m, Even[int]) }},
+			map[int]string{2: "two", 4: "four"}},
+		{"values", args{func(m MapIterator[int, string]) MapIterator[int, string] {
+			return FilterValues(m, func(v string) bool { return len(v) > 3 })
+		}}, map[int]string{3: "three", 4: "four"}},
+		{"entries", args{func(m MapIterator[int, string]) MapIterator[int, string] {
+			return FilterEntries(m, func(k int, v string) bool { return k > 1 && len(v) == 3 })
+		}}, map[int]string{2: "two"}},
+		{"pick", args{func(m MapIterator[int, string]) MapIterator[int, string] { return PickKeys(m, 1, 3, 5) }},
+			map[int]string{1: "one", 3: "three"}},
+		{"omit", args{func(m MapIterator[int, string]) MapIterator[int, string] { return OmitKeys(m, 1, 3, 5) }},
+			map[int]string{2: "two", 4: "four"}},
+		{"chain", args{func(m MapIterator[int, string]) MapIterator[int, string] {
+			return OmitKeys(FilterKeys(m, Even[int]), 4)
+		}}, map[int]string{2: "two"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.args.fn(M(m))
+			require.Equal(t, tt.want, got.ToMap())
+			require.Equal(t, len(tt.want), len(got.Items().Slice()))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package iter
+
+import "sync"
+
+// Reusable is an iterator that can be rewound to its first element
+// S(), Of(), Range() and iterators from Memoize() are reusable,
+// C(), map iterators and operators are one-shot, wrap them with Memoize() to replay
+type Reusable[T any] interface {
+	Iterator[T]
+	Reset()
+}
+
+type reusable[T any] struct {
+	*withNext[T]
+	reset func()
+}
+
+func (it *reusable[T]) Reset() { it.reset() }
+
+// Memoize cache elements of it and return factory of iterators replaying them
+// it is pulled lazily when a spawned iterator goes past the cached elements
+// spawned iterators can be consumed concurrently
+func Memoize[T any](it Iterator[T]) func() Reusable[T] {
+	var mu sync.Mutex
+	var cache []T
+	done := false
+
+	get := func(i int) (r T, ok bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for i >= len(cache) && !done {
+			v, ok := it.Next()
+			if !ok {
+				done = true
+				break
+			}
+			cache = append(cache, v)
+		}
+
+		if i < len(cache) {
+			return cache[i], true
+		}
+		return r, false
+	}
+
+	return func() Reusable[T] {
+		index := 0
+		return &reusable[T]{
+			withNext: &withNext[T]{
+				name:    "memoize",
+				parents: []any{it},
+				next: func() (T, bool) {
+					v, ok := get(index)
+					if ok {
+						index++
+					}
+					return v, ok
+				},
+				hint: func() bounds {
+					mu.Lock()
+					defer mu.Unlock()
+
+					cached := exactSize(len(cache) - index)
+					if done {
+						return cached
+					}
+					return cached.add(sizeOf(it))
+				},
+			},
+			reset: func() { index = 0 },
+		}
+	}
+}
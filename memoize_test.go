@@ -0,0 +1,76 @@
+package iter
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReusable(t *testing.T) {
+	tests := [...]struct {
+		name string
+		it   Iterator[int]
+		want []int
+	}{
+		{`S`, S([]int{1, 2, 3}), []int{1, 2, 3}},
+		{`Of`, Of(1, 2, 3), []int{1, 2, 3}},
+		{`Range`, Range(1, 4), []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ok := tt.it.(Reusable[int])
+			require.True(t, ok)
+
+			require.Equal(t, tt.want, r.Slice())
+			require.Empty(t, r.Slice())
+
+			r.Reset()
+			require.Equal(t, tt.want, r.Slice())
+		})
+	}
+}
+
+func TestOneShot(t *testing.T) {
+	ch := make(chan int)
+	close(ch)
+
+	_, ok := C(ch).(Reusable[int])
+	require.False(t, ok)
+	_, ok = Of(1).Map(Multiply(2)).(Reusable[int])
+	require.False(t, ok)
+}
+
+func TestMemoize(t *testing.T) {
+	pulled := 0
+	spawn := Memoize(counter(Of(1, 2, 3), &pulled))
+	require.Equal(t, 0, pulled)
+
+	first := spawn()
+	v, _ := first.Next()
+	require.Equal(t, 1, v)
+	require.Equal(t, 1, pulled)
+
+	require.Equal(t, []int{1, 2, 3}, spawn().Slice())
+	require.Equal(t, []int{2, 3}, first.Slice())
+	require.Equal(t, 3, pulled)
+
+	first.Reset()
+	require.Equal(t, []int{1, 2, 3}, first.Slice())
+	require.Equal(t, 3, pulled)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	spawn := Memoize(Map(Range(0, 100), Multiply(2)))
+	want := Map(Range(0, 100), Multiply(2)).Slice()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, want, spawn().Slice())
+		}()
+	}
+	wg.Wait()
+}
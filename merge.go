@@ -0,0 +1,219 @@
+package iter
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+func closeAll[T any](its []Iterator[T]) {
+	for _, it := range its {
+		Close(it)
+	}
+}
+
+// Merge emit elements from whichever source is ready, see MergeCtx()
+func Merge[T any](its ...Iterator[T]) Iterator[T] { return MergeCtx(context.Background(), its...) }
+
+// MergeCtx emit elements from whichever source is ready until all sources end or ctx is done
+// each source is pulled and closed by its own goroutine, Close() or cancelling ctx releases them
+// a goroutine blocked in Next() of its source is released when the source returns
+func MergeCtx[T any](ctx context.Context, its ...Iterator[T]) Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	var out chan T
+
+	run := func() {
+		out = make(chan T)
+		var wg sync.WaitGroup
+		for _, it := range its {
+			wg.Add(1)
+			go func(it Iterator[T]) {
+				defer wg.Done()
+				defer Close(it)
+				for v, ok := it.Next(); ok; v, ok = it.Next() {
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(it)
+		}
+
+		go func() {
+			wg.Wait()
+			close(out)
+			cancel()
+		}()
+	}
+
+	return &withNext[T]{
+		name:    "merge",
+		parents: ups(its),
+		next: func() (r T, ok bool) {
+			if out == nil {
+				run()
+			}
+
+			if ctx.Err() != nil {
+				return r, false
+			}
+
+			select {
+			case v, ok := <-out:
+				return v, ok
+			case <-ctx.Done():
+				return r, false
+			}
+		},
+		close: func() {
+			cancel()
+			if out == nil {
+				closeAll(its)
+			}
+		},
+	}
+}
+
+// Interleave take an element from each source in turn and stop when any source ends
+func Interleave[T any](its ...Iterator[T]) Iterator[T] {
+	i := 0
+	done := len(its) == 0
+
+	return &withNext[T]{
+		name:    "interleave",
+		parents: ups(its),
+		next: func() (r T, ok bool) {
+			if done {
+				return r, false
+			}
+
+			v, ok := its[i].Next()
+			if !ok {
+				done = true
+				return r, false
+			}
+
+			i = (i + 1) % len(its)
+			return v, true
+		},
+		close: func() { closeAll(its) },
+	}
+}
+
+// RoundRobin take an element from each source in turn, skipping ended sources, until all sources end
+func RoundRobin[T any](its ...Iterator[T]) Iterator[T] {
+	live := append([]Iterator[T](nil), its...)
+	i := 0
+
+	return &withNext[T]{
+		name:    "roundRobin",
+		parents: ups(its),
+		next: func() (r T, ok bool) {
+			for len(live) > 0 {
+				i %= len(live)
+				if v, ok := live[i].Next(); ok {
+					i++
+					return v, true
+				}
+				live = append(live[:i], live[i+1:]...)
+			}
+			return r, false
+		},
+		close: func() { closeAll(its) },
+	}
+}
+
+// Priority emit elements from whichever source is ready like Merge(), preferring earlier sources when several are ready
+// each source is pulled one element ahead and closed by its own goroutine
+func Priority[T any](its ...Iterator[T]) Iterator[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	var chans []<-chan T
+	live := 0
+
+	run := func() {
+		chans = make([]<-chan T, len(its))
+		for i, it := range its {
+			chans[i] = pumpN(ctx, it, 1)
+		}
+		live = len(chans)
+	}
+
+	// wait block until any source is ready
+	wait := func() (int, T, bool) {
+		cases := make([]reflect.SelectCase, 0, len(chans)+1)
+		index := make([]int, 0, len(chans))
+		for i, ch := range chans {
+			if ch != nil {
+				cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+				index = append(index, i)
+			}
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+
+		chosen, v, ok := reflect.Select(cases)
+		if chosen == len(index) {
+			var r T
+			return -1, r, false
+		}
+		if !ok {
+			var r T
+			return index[chosen], r, false
+		}
+		r, _ := v.Interface().(T) // nil interface element
+		return index[chosen], r, true
+	}
+
+	return &withNext[T]{
+		name:    "priority",
+		parents: ups(its),
+		next: func() (r T, ok bool) {
+			if chans == nil {
+				run()
+			}
+
+			if ctx.Err() != nil {
+				return r, false
+			}
+
+		loop:
+			for live > 0 {
+				for i, ch := range chans {
+					if ch == nil {
+						continue
+					}
+
+					select {
+					case v, ok := <-ch:
+						if !ok {
+							chans[i] = nil
+							live--
+							continue loop
+						}
+						return v, true
+					default:
+					}
+				}
+
+				i, v, ok := wait()
+				switch {
+				case i < 0:
+					return r, false
+				case !ok:
+					chans[i] = nil
+					live--
+				default:
+					return v, true
+				}
+			}
+			cancel()
+			return r, false
+		},
+		close: func() {
+			cancel()
+			if chans == nil {
+				closeAll(its)
+			}
+		},
+	}
+}
@@ -0,0 +1,177 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func feed[T any](s ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range s {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// waitGoroutines wait until number of goroutines goes down to n
+func waitGoroutines(t *testing.T, n int) {
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > n && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	require.LessOrEqual(t, runtime.NumGoroutine(), n)
+}
+
+func TestMerge(t *testing.T) {
+	it := Merge(C(feed(1, 2, 3)), C(feed(4, 5)), Of[int](), Of(6))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, Sorted(it).Slice())
+
+	require.Empty(t, Merge[int]().Slice())
+}
+
+func TestMergeCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := MergeCtx(ctx, Range(0, math.MaxInt), Range(0, math.MaxInt))
+	for i := 0; i < 10; i++ {
+		_, ok := it.Next()
+		require.True(t, ok)
+	}
+	cancel()
+
+	_, ok := it.Next()
+	require.False(t, ok)
+	waitGoroutines(t, before)
+}
+
+func TestMergeClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	it := Merge(Range(0, math.MaxInt), Range(0, math.MaxInt))
+	it.Next()
+	Close(it)
+	waitGoroutines(t, before)
+}
+
+// slowSource is a source whose Close() races with its Next() if they are called from different goroutines
+func slowSource() Iterator[int] {
+	n := 0
+	return &withNext[int]{
+		next: func() (int, bool) {
+			time.Sleep(time.Millisecond)
+			n++
+			return n, true
+		},
+		close: func() { n = -1 },
+	}
+}
+
+func TestMergeCloseRace(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for _, it := range []Iterator[int]{Merge(slowSource(), slowSource()), Priority(slowSource(), slowSource())} {
+		_, ok := it.Next()
+		require.True(t, ok)
+		Close(it)
+		_, ok = it.Next()
+		require.False(t, ok)
+	}
+	waitGoroutines(t, before)
+
+	// closed before started
+	closed := 0
+	src := &withNext[int]{next: slowSource().Next, close: func() { closed++ }}
+	Close(Merge[int](src))
+	Close(Priority[int](src))
+	require.Equal(t, 2, closed)
+}
+
+func TestInterleave(t *testing.T) {
+	require.Equal(t, []int{1, 10, 2, 20, 3}, Interleave(Of(1, 2, 3, 4), Of(10, 20)).Slice())
+	require.Empty(t, Interleave[int]().Slice())
+}
+
+func TestRoundRobin(t *testing.T) {
+	require.Equal(t, []int{1, 10, 100, 2, 20, 3, 4}, RoundRobin(Of(1, 2, 3, 4), Of(10, 20), Of(100)).Slice())
+	require.Empty(t, RoundRobin[int]().Slice())
+}
+
+func TestPriority(t *testing.T) {
+	ch := make(chan int)
+	it := Priority(Of(1, 2, 3), C(ch))
+	for _, want := range []int{1, 2, 3} {
+		v, ok := it.Next()
+		require.True(t, ok)
+		require.Equal(t, want, v)
+	}
+
+	go func() {
+		ch <- 10
+		close(ch)
+	}()
+	require.Equal(t, []int{10}, it.Slice())
+}
+
+func TestPriorityPreferEarlier(t *testing.T) {
+	// pulled signals each Next() of a source, so the element before it is ready in the pump
+	source := func(s ...int) (Iterator[int], chan struct{}) {
+		pulled := make(chan struct{}, len(s)+1)
+		it := Of(s...)
+		return &withNext[int]{next: func() (int, bool) {
+			v, ok := it.Next()
+			pulled <- struct{}{}
+			return v, ok
+		}}, pulled
+	}
+	wait := func(pulled chan struct{}, n int) {
+		for i := 0; i < n; i++ {
+			<-pulled
+		}
+	}
+
+	high, highPulled := source(1, 2, 3)
+	low, lowPulled := source(10, 20, 30)
+	it := Priority(high, low)
+
+	first, ok := it.Next() // start pumps
+	require.True(t, ok)
+	fromHigh := 0
+	if first < 10 {
+		fromHigh = 1
+	}
+
+	// both sources have an element ready
+	wait(highPulled, fromHigh+2)
+	wait(lowPulled, 1-fromHigh+2)
+
+	v, ok := it.Next()
+	require.True(t, ok)
+	require.Equal(t, fromHigh+1, v, "earlier source should be preferred when both are ready")
+	require.ElementsMatch(t, []int{1, 2, 3, 10, 20, 30}, append([]int{first, v}, it.Slice()...))
+}
+
+func TestPriorityNilInterface(t *testing.T) {
+	err := errors.New("x")
+	require.Equal(t, []error{nil, err}, Priority[error](Of[error](nil, err)).Slice())
+}
+
+func TestPriorityClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	it := Priority(Range(0, math.MaxInt), Range(0, math.MaxInt))
+	it.Next()
+	Close(it)
+
+	_, ok := it.Next()
+	require.False(t, ok)
+	waitGoroutines(t, before)
+}
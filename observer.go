@@ -0,0 +1,255 @@
+package iter
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Observer receive runtime events of pipeline stages, set it with SetObserver()
+// methods are called concurrently from stage goroutines
+type Observer interface {
+	OnElement(stage string, latency time.Duration) // stage produced an element in latency
+	OnQueue(stage string, length, capacity int)    // queue occupancy after push or pop
+	OnGoroutine(stage string, delta int)           // goroutine started(+1) or finished(-1)
+}
+
+type observerHolder struct{ Observer }
+
+var globalObserver atomic.Pointer[observerHolder]
+
+// SetObserver set observer for all stages, nil disables observation
+func SetObserver(o Observer) {
+	if o == nil {
+		globalObserver.Store(nil)
+		return
+	}
+	globalObserver.Store(&observerHolder{o})
+}
+
+func currentObserver() Observer {
+	if h := globalObserver.Load(); h != nil {
+		return h.Observer
+	}
+	return nil
+}
+
+// stage label a pipeline stage for observer, nil stage is not observed
+type stage struct {
+	name     atomic.Pointer[string]
+	elements atomic.Int64 // counted even when not observed, see Describe()
+}
+
+func newStage(name string) *stage {
+	s := &stage{}
+	s.SetName(name)
+	return s
+}
+
+func (s *stage) Name() string {
+	if s == nil {
+		return ""
+	}
+	return *s.name.Load()
+}
+
+func (s *stage) SetName(name string) { s.name.Store(&name) }
+
+// start return start time of an element when observed
+func (s *stage) start() time.Time {
+	if s == nil || currentObserver() == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (s *stage) element(start time.Time) {
+	if s == nil {
+		return
+	}
+	s.elements.Add(1)
+	if start.IsZero() {
+		return
+	}
+	if o := currentObserver(); o != nil {
+		o.OnElement(s.Name(), time.Since(start))
+	}
+}
+
+func (s *stage) queue(length, capacity int) {
+	if s == nil {
+		return
+	}
+	if o := currentObserver(); o != nil {
+		o.OnQueue(s.Name(), length, capacity)
+	}
+}
+
+// goroutine record a started goroutine and return func to record it finished
+func (s *stage) goroutine() func() {
+	o := currentObserver()
+	if s == nil || o == nil {
+		return func() {}
+	}
+
+	name := s.Name()
+	o.OnGoroutine(name, 1)
+	return func() { o.OnGoroutine(name, -1) }
+}
+
+// Named label the stage of it as name
+// named stage is not fused with following operators to keep it observable
+func Named[T any](name string, it Iterator[T]) Iterator[T] {
+	if w, ok := it.(*withNext[T]); ok && w.stage != nil {
+		w.stage.SetName(name)
+		w.name = ""
+		w.fused = nil
+		return it
+	}
+
+	s := newStage(name)
+	return &withNext[T]{
+		stage: s,
+		next: func() (T, bool) {
+			start := s.start()
+			v, ok := it.Next()
+			if ok {
+				s.element(start)
+			}
+			return v, ok
+		},
+		close:   func() { Close(it) },
+		hint:    func() bounds { return sizeOf(it) },
+		parents: []any{it},
+	}
+}
+
+// LatencyBuckets is upper bounds of Histogram buckets, the last bucket counts the rest
+var LatencyBuckets = []time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+type Histogram struct {
+	Count   int64
+	Sum     time.Duration
+	Buckets []int64 // Buckets[i] counts latencies up to LatencyBuckets[i]
+}
+
+func (h *Histogram) add(d time.Duration) {
+	if h.Buckets == nil {
+		h.Buckets = make([]int64, len(LatencyBuckets)+1)
+	}
+
+	i := 0
+	for i < len(LatencyBuckets) && d > LatencyBuckets[i] {
+		i++
+	}
+	h.Buckets[i]++
+	h.Count++
+	h.Sum += d
+}
+
+func (h Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+type StageStats struct {
+	Elements      int64
+	Latency       Histogram
+	QueueLen      int
+	QueueCap      int
+	QueuePeak     int
+	Goroutines    int
+	GoroutinePeak int
+}
+
+// MemoryObserver aggregate stage events in memory
+type MemoryObserver struct {
+	mu     sync.Mutex
+	stages map[string]*StageStats
+}
+
+func NewMemoryObserver() *MemoryObserver {
+	return &MemoryObserver{stages: map[string]*StageStats{}}
+}
+
+func (o *MemoryObserver) get(name string) *StageStats {
+	s, ok := o.stages[name]
+	if !ok {
+		s = &StageStats{}
+		o.stages[name] = s
+	}
+	return s
+}
+
+func (o *MemoryObserver) OnElement(name string, latency time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s := o.get(name)
+	s.Elements++
+	s.Latency.add(latency)
+}
+
+func (o *MemoryObserver) OnQueue(name string, length, capacity int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s := o.get(name)
+	s.QueueLen, s.QueueCap = length, capacity
+	s.QueuePeak = max(s.QueuePeak, length)
+}
+
+func (o *MemoryObserver) OnGoroutine(name string, delta int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s := o.get(name)
+	s.Goroutines += delta
+	s.GoroutinePeak = max(s.GoroutinePeak, s.Goroutines)
+}
+
+// Stats return copy of stats of the stage
+func (o *MemoryObserver) Stats(name string) StageStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if s, ok := o.stages[name]; ok {
+		return s.clone()
+	}
+	return StageStats{}
+}
+
+// Snapshot return copy of stats of all stages
+func (o *MemoryObserver) Snapshot() map[string]StageStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	r := make(map[string]StageStats, len(o.stages))
+	for name, s := range o.stages {
+		r[name] = s.clone()
+	}
+	return r
+}
+
+func (s *StageStats) clone() StageStats {
+	r := *s
+	r.Latency.Buckets = append([]int64(nil), s.Latency.Buckets...)
+	return r
+}
+
+// PublishExpvar export snapshot of o as expvar name, it panics if name is already published
+func PublishExpvar(name string, o *MemoryObserver) {
+	expvar.Publish(name, expvar.Func(func() any { return o.Snapshot() }))
+}
@@ -0,0 +1,91 @@
+package iter
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setMemoryObserver(t *testing.T) *MemoryObserver {
+	obs := NewMemoryObserver()
+	SetObserver(obs)
+	t.Cleanup(func() { SetObserver(nil) })
+	return obs
+}
+
+func TestObserver(t *testing.T) {
+	obs := setMemoryObserver(t)
+
+	it := Named("double", Map(Of(1, 2, 3, 4, 5, 6), Multiply(2)))
+	it = Named("even", filter(it, func(x int) bool { return x%4 == 0 }))
+	require.Equal(t, []int{4, 8, 12}, it.Slice())
+
+	require.Eventually(t, func() bool { return obs.Stats("double").Goroutines == 0 }, time.Second, time.Millisecond)
+
+	double := obs.Stats("double")
+	require.Equal(t, int64(6), double.Elements)
+	require.Equal(t, int64(6), double.Latency.Count)
+	require.Greater(t, double.GoroutinePeak, 0)
+	require.Greater(t, double.QueueCap, 0)
+	require.Equal(t, 0, double.QueueLen)
+
+	require.Equal(t, int64(3), obs.Stats("even").Elements)
+}
+
+func TestObserverStages(t *testing.T) {
+	obs := setMemoryObserver(t)
+
+	Concat(Of(1, 2), Of(3)).TakeWhile(func(x int) bool { return x < 3 }).Each(func(int) {})
+	Of(1, 2, 3).DropWhile(func(x int) bool { return x < 2 }).Slice()
+
+	require.Eventually(t, func() bool { return obs.Stats("fanOut").Goroutines == 0 }, time.Second, time.Millisecond)
+
+	snapshot := obs.Snapshot()
+	require.Equal(t, int64(3), snapshot["concat"].Elements)
+	require.Equal(t, int64(2), snapshot["takeWhile"].Elements)
+	require.Equal(t, int64(2), snapshot["fanOut"].Elements)
+	require.Equal(t, int64(2), snapshot["dropWhile"].Elements)
+}
+
+func TestObserverNamedSource(t *testing.T) {
+	obs := setMemoryObserver(t)
+
+	require.Equal(t, []int{1, 2, 3}, Named("source", Of(1, 2, 3)).Slice())
+	require.Equal(t, int64(3), obs.Stats("source").Elements)
+}
+
+func TestObserverDisabled(t *testing.T) {
+	obs := setMemoryObserver(t)
+	SetObserver(nil)
+
+	Named("none", Of(1, 2, 3)).Slice()
+	require.Empty(t, obs.Snapshot())
+}
+
+func TestHistogram(t *testing.T) {
+	h := Histogram{}
+	h.add(time.Microsecond)
+	h.add(5 * time.Millisecond)
+	h.add(time.Minute)
+
+	require.Equal(t, int64(3), h.Count)
+	require.Equal(t, int64(1), h.Buckets[0])
+	require.Equal(t, int64(1), h.Buckets[4])
+	require.Equal(t, int64(1), h.Buckets[len(LatencyBuckets)])
+	require.Equal(t, (time.Microsecond+5*time.Millisecond+time.Minute)/3, h.Mean())
+}
+
+func TestPublishExpvar(t *testing.T) {
+	obs := NewMemoryObserver()
+	obs.OnElement("stage", time.Millisecond)
+	name := fmt.Sprintf("iter_test_observer_%p", obs)
+	PublishExpvar(name, obs)
+
+	got := map[string]StageStats{}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &got))
+	require.Equal(t, int64(1), got["stage"].Elements)
+}
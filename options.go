@@ -0,0 +1,77 @@
+package iter
+
+import "time"
+
+// Option configure optional behaviors of operators
+type Option func(*options)
+
+type options struct {
+	clock       Clock
+	buffer      int
+	sequential  bool
+	concurrency int
+	ordered     bool
+
+	rateN   int
+	ratePer time.Duration
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		clock: systemClock{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithClock set time source, default is the system clock
+func WithClock(clock Clock) Option { return func(o *options) { o.clock = clock } }
+
+// WithBuffer set buffer size of queue between goroutines of the operator
+// TakeWhile(), DropWhile() and ConcatOpts() run synchronously unless it is given
+func WithBuffer(n int) Option { return func(o *options) { o.buffer = n } }
+
+// WithConcurrency limit number of goroutines running the function of the operator at the same time
+func WithConcurrency(n int) Option { return func(o *options) { o.concurrency = n } }
+
+// WithSequential run the operator on the consumer goroutine
+func WithSequential() Option { return func(o *options) { o.sequential = true } }
+
+// WithOrdered emit results in input order, for operators which emit them as they are ready by default
+func WithOrdered() Option { return func(o *options) { o.ordered = true } }
+
+// sequential return copy of opts with WithSequential()
+func sequential(opts []Option) []Option {
+	return append(opts[:len(opts):len(opts)], WithSequential())
+}
+
+// WithRateLimit limit the operator to start at most n calls per duration
+func WithRateLimit(n int, per time.Duration) Option {
+	return func(o *options) { o.rateN, o.ratePer = n, per }
+}
+
+func (o *options) limiter() *tokenBucket { return newTokenBucket(o.clock, o.rateN, o.ratePer) }
+
+// semaphore limit concurrent goroutines, nil semaphore means no limit
+type semaphore chan struct{}
+
+func (o *options) semaphore() semaphore {
+	if o.concurrency <= 0 {
+		return nil
+	}
+	return make(semaphore, o.concurrency)
+}
+
+func (s semaphore) acquire() {
+	if s != nil {
+		s <- struct{}{}
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
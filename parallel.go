@@ -0,0 +1,38 @@
+package iter
+
+import "runtime"
+
+// ParallelFilter is Filter() evaluating pred concurrently in input order, see FilterMap()
+func ParallelFilter[T any](it Iterator[T], pred func(T) bool, opts ...Option) Iterator[T] {
+	return FilterMap(it, func(v T) (T, bool) { return v, pred(v) }, opts...)
+}
+
+// FilterMap map elements with fn and keep results whose ok is true, in input order
+// fn runs concurrently like Map(), at most runtime.GOMAXPROCS() at the same time unless WithConcurrency() is given
+func FilterMap[T1, T2 any](it Iterator[T1], fn func(T1) (T2, bool), opts ...Option) Iterator[T2] {
+	type result struct {
+		v  T2
+		ok bool
+	}
+
+	opts = append([]Option{WithConcurrency(runtime.GOMAXPROCS(0))}, opts...)
+	results := Map(it, func(v T1) result {
+		r, ok := fn(v)
+		return result{r, ok}
+	}, opts...)
+
+	return &withNext[T2]{
+		name:    "filterMap",
+		parents: []any{results},
+		next: func() (r T2, ok bool) {
+			for res, ok := results.Next(); ok; res, ok = results.Next() {
+				if res.ok {
+					return res.v, true
+				}
+			}
+			return r, false
+		},
+		close: func() { Close(results) },
+		hint:  func() bounds { return sizeOf(results).atMost() },
+	}
+}
@@ -0,0 +1,100 @@
+package iter
+
+import (
+	"math"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelFilter(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := [...]struct {
+		name string
+		args args
+	}{
+		{"default", args{nil}},
+		{"concurrency 1", args{[]Option{WithConcurrency(1)}}},
+		{"concurrency 8", args{[]Option{WithConcurrency(8)}}},
+		{"sequential", args{[]Option{WithSequential()}}},
+		{"buffer", args{[]Option{WithBuffer(2)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParallelFilter(Range(0, 100), func(x int) bool {
+				time.Sleep(time.Duration(100-x) * time.Microsecond)
+				return x%3 == 0
+			}, tt.args.opts...).Slice()
+			require.Equal(t, Range(0, 100, 3).Slice(), got)
+		})
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	got := FilterMap(Of("1", "x", "3", "", "5"), func(s string) (int, bool) {
+		v, err := strconv.Atoi(s)
+		return v, err == nil
+	}).Slice()
+	require.Equal(t, []int{1, 3, 5}, got)
+
+	require.Nil(t, FilterMap(Of[int](), func(x int) (int, bool) { return x, true }).Slice())
+}
+
+// concurrencyProbe record peak number of calls running between enter() and leave()
+// the first n calls wait in enter() until all of them entered, so the peak reaches n when calls run concurrently
+type concurrencyProbe struct {
+	running, peak, entered atomic.Int32
+	n                      int32
+	all                    chan struct{}
+}
+
+func newConcurrencyProbe(n int) *concurrencyProbe {
+	return &concurrencyProbe{n: int32(n), all: make(chan struct{})}
+}
+
+func (c *concurrencyProbe) enter() {
+	n := c.running.Add(1)
+	for p := c.peak.Load(); n > p && !c.peak.CompareAndSwap(p, n); p = c.peak.Load() {
+	}
+
+	switch e := c.entered.Add(1); {
+	case e == c.n:
+		close(c.all)
+	case e < c.n:
+		select {
+		case <-c.all:
+		case <-time.After(time.Second): // calls are serialized, the peak assertion fails
+		}
+	}
+}
+
+func (c *concurrencyProbe) leave()      { c.running.Add(-1) }
+func (c *concurrencyProbe) Peak() int32 { return c.peak.Load() }
+
+func TestFilterMapConcurrency(t *testing.T) {
+	probe := newConcurrencyProbe(2)
+	pred := func(x int) bool {
+		probe.enter()
+		defer probe.leave()
+		return true
+	}
+
+	require.Len(t, ParallelFilter(Range(0, 50), pred, WithConcurrency(4)).Slice(), 50)
+	require.LessOrEqual(t, probe.Peak(), int32(4))
+	require.GreaterOrEqual(t, probe.Peak(), int32(2), "predicates should run concurrently")
+}
+
+func TestFilterMapClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	v, ok := Find(ParallelFilter(Range(0, math.MaxInt), Even[int]), func(x int) bool { return x > 10 })
+	require.True(t, ok)
+	require.Equal(t, 12, v)
+	waitGoroutines(t, before)
+}
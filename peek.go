@@ -0,0 +1,84 @@
+package iter
+
+// PeekableIterator is an iterator with lookahead and push-back
+type PeekableIterator[T any] interface {
+	Iterator[T]
+
+	Peek() (T, bool)               // return the next element without consuming it
+	PeekN(n int) []T               // return up to n next elements without consuming them
+	Unread(v T)                    // push v back, it is returned by the next Next()
+	NextIf(func(T) bool) (T, bool) // consume the next element only if it matches
+	NextWhile(func(T) bool) []T    // consume elements while they match
+}
+
+type peekable[T any] struct {
+	*withNext[T]
+	src Iterator[T]
+	buf []T // lookahead and pushed back elements in order
+}
+
+// Peekable wrap it to support lookahead
+func Peekable[T any](it Iterator[T]) PeekableIterator[T] {
+	if p, ok := it.(PeekableIterator[T]); ok {
+		return p
+	}
+
+	p := &peekable[T]{src: it}
+	p.withNext = &withNext[T]{
+		name:    "peekable",
+		parents: []any{p.src},
+		next: func() (T, bool) {
+			if len(p.buf) > 0 {
+				v := p.buf[0]
+				p.buf = p.buf[1:]
+				return v, true
+			}
+			return p.src.Next()
+		},
+		close: func() { Close(p.src) },
+		hint:  func() bounds { return exactSize(len(p.buf)).add(sizeOf(p.src)) },
+	}
+	return p
+}
+
+// fill pull from src until buffer has n elements
+func (p *peekable[T]) fill(n int) {
+	for len(p.buf) < n {
+		v, ok := p.src.Next()
+		if !ok {
+			return
+		}
+		p.buf = append(p.buf, v)
+	}
+}
+
+func (p *peekable[T]) Peek() (r T, ok bool) {
+	p.fill(1)
+	if len(p.buf) == 0 {
+		return r, false
+	}
+	return p.buf[0], true
+}
+
+func (p *peekable[T]) PeekN(n int) []T {
+	n = max(n, 0)
+	p.fill(n)
+	return append([]T(nil), p.buf[:min(n, len(p.buf))]...)
+}
+
+func (p *peekable[T]) Unread(v T) { p.buf = append([]T{v}, p.buf...) }
+
+func (p *peekable[T]) NextIf(pred func(T) bool) (r T, ok bool) {
+	v, ok := p.Peek()
+	if !ok || !pred(v) {
+		return r, false
+	}
+	return p.Next()
+}
+
+func (p *peekable[T]) NextWhile(pred func(T) bool) (r []T) {
+	for v, ok := p.NextIf(pred); ok; v, ok = p.NextIf(pred) {
+		r = append(r, v)
+	}
+	return r
+}
@@ -0,0 +1,77 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeekable(t *testing.T) {
+	it := Peekable(Of(1, 2, 3, 4, 5))
+
+	v, ok := it.Peek()
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.Equal(t, []int{1, 2, 3}, it.PeekN(3))
+	require.Empty(t, it.PeekN(0))
+	require.Empty(t, it.PeekN(-1))
+
+	v, _ = it.Next()
+	require.Equal(t, 1, v)
+
+	it.Unread(0)
+	require.Equal(t, []int{0, 2}, it.PeekN(2))
+
+	_, ok = it.NextIf(func(x int) bool { return x > 0 })
+	require.False(t, ok)
+	v, ok = it.NextIf(func(x int) bool { return x == 0 })
+	require.True(t, ok)
+	require.Equal(t, 0, v)
+
+	require.Equal(t, []int{2, 3}, it.NextWhile(func(x int) bool { return x < 4 }))
+	require.Equal(t, []int{4, 5}, it.PeekN(10))
+	require.Equal(t, []int{4, 5}, it.Slice())
+
+	_, ok = it.Peek()
+	require.False(t, ok)
+	require.Empty(t, it.PeekN(1))
+}
+
+func TestPeekableSources(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	it := Peekable(Map(C(ch), Multiply(10)))
+	require.Equal(t, []int{10, 20}, it.PeekN(2))
+	require.Equal(t, []int{10, 20, 30}, it.Slice())
+
+	require.Same(t, it, Peekable[int](it))
+}
+
+// tokenize split s into words and numbers using lookahead
+func tokenize(s string) (tokens []string) {
+	it := Peekable(S([]rune(s)))
+	for r, ok := it.Peek(); ok; r, ok = it.Peek() {
+		switch {
+		case unicode.IsSpace(r):
+			it.Next()
+		case unicode.IsDigit(r):
+			tokens = append(tokens, string(it.NextWhile(unicode.IsDigit)))
+		case unicode.IsLetter(r):
+			tokens = append(tokens, string(it.NextWhile(unicode.IsLetter)))
+		default:
+			v, _ := it.Next()
+			tokens = append(tokens, string(v))
+		}
+	}
+	return tokens
+}
+
+func TestPeekableTokenize(t *testing.T) {
+	require.Equal(t, strings.Fields("abc = 123 + x 1 ;"), tokenize("abc=123 + x1;"))
+}
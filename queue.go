@@ -0,0 +1,182 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrQueueClosed = errors.New("queue closed")
+	ErrQueueFull   = errors.New("queue full")
+)
+
+// OverflowPolicy decide what Push does when the queue is full
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // wait until there is room
+	OverflowDropOldest                       // drop the oldest element to make room
+	OverflowDropNewest                       // drop the element being pushed
+	OverflowError                            // fail with ErrQueueFull
+)
+
+type QueueOption func(*queueOptions)
+
+type queueOptions struct {
+	capacity int
+	policy   OverflowPolicy
+}
+
+// QueueCapacity set buffer size of the queue, default is runtime.NumCPU()
+func QueueCapacity(n int) QueueOption { return func(o *queueOptions) { o.capacity = n } }
+
+// QueueOverflow set overflow policy of the queue, default is OverflowBlock
+func QueueOverflow(p OverflowPolicy) QueueOption { return func(o *queueOptions) { o.policy = p } }
+
+// Queue is a bounded FIFO queue safe for concurrent use
+// pushing after Close() does not panic but fails with ErrQueueClosed
+type Queue[T any] struct {
+	items   chan T
+	policy  OverflowPolicy
+	dropped atomic.Int64
+	stage   *stage
+
+	mu        sync.RWMutex
+	closed    bool
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewQueue[T any](opts ...QueueOption) *Queue[T] {
+	o := &queueOptions{capacity: runtime.NumCPU()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	capacity := o.capacity
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Queue[T]{
+		items:  make(chan T, capacity),
+		policy: o.policy,
+		done:   make(chan struct{}),
+	}
+}
+
+func newQueue[T any](opts *options) *Queue[T] {
+	if opts.buffer > 0 {
+		return NewQueue[T](QueueCapacity(opts.buffer))
+	}
+	return NewQueue[T]()
+}
+
+func (q *Queue[T]) Len() int       { return len(q.items) }
+func (q *Queue[T]) Cap() int       { return cap(q.items) }
+func (q *Queue[T]) Dropped() int64 { return q.dropped.Load() }
+
+// Close close the queue, remaining elements can still be popped
+func (q *Queue[T]) Close() {
+	q.closeOnce.Do(func() {
+		close(q.done) // release blocked pushers before taking the lock
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.closed = true
+		close(q.items)
+	})
+}
+
+// Push push v and return it, errors are ignored
+func (q *Queue[T]) Push(v T) T {
+	q.PushCtx(context.Background(), v)
+	return v
+}
+
+// PushCtx push v by overflow policy
+func (q *Queue[T]) PushCtx(ctx context.Context, v T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	if err := q.push(ctx, v); err != nil {
+		return err
+	}
+	q.stage.queue(len(q.items), cap(q.items))
+	return nil
+}
+
+func (q *Queue[T]) push(ctx context.Context, v T) error {
+	switch q.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.items <- v:
+				return nil
+			default:
+			}
+
+			select {
+			case <-q.items:
+				q.dropped.Add(1)
+			default:
+			}
+		}
+
+	case OverflowDropNewest:
+		select {
+		case q.items <- v:
+		default:
+			q.dropped.Add(1)
+		}
+		return nil
+
+	case OverflowError:
+		select {
+		case q.items <- v:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+
+	default:
+		select {
+		case q.items <- v:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.done:
+			return ErrQueueClosed
+		}
+	}
+}
+
+// Pop pop an element, false if the queue is closed and empty
+func (q *Queue[T]) Pop() (T, bool) {
+	v, ok := <-q.items
+	if ok {
+		q.stage.queue(len(q.items), cap(q.items))
+	}
+	return v, ok
+}
+
+// PopCtx pop an element, ErrQueueClosed if the queue is closed and empty
+func (q *Queue[T]) PopCtx(ctx context.Context) (r T, err error) {
+	select {
+	case v, ok := <-q.items:
+		if !ok {
+			return r, ErrQueueClosed
+		}
+		q.stage.queue(len(q.items), cap(q.items))
+		return v, nil
+	case <-ctx.Done():
+		return r, ctx.Err()
+	}
+}
@@ -0,0 +1,107 @@
+package iter
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func drain[T any](q *Queue[T]) (r []T) {
+	for v, ok := q.Pop(); ok; v, ok = q.Pop() {
+		r = append(r, v)
+	}
+	return r
+}
+
+func TestQueue(t *testing.T) {
+	q := NewQueue[int]()
+	require.Equal(t, runtime.NumCPU(), q.Cap())
+
+	q = NewQueue[int](QueueCapacity(3))
+	require.Equal(t, 3, q.Cap())
+	require.Equal(t, 1, q.Push(1))
+	require.NoError(t, q.PushCtx(context.Background(), 2))
+	require.Equal(t, 2, q.Len())
+
+	q.Close()
+	require.Equal(t, []int{1, 2}, drain(q))
+}
+
+func TestQueueOverflow(t *testing.T) {
+	tests := [...]struct {
+		name    string
+		policy  OverflowPolicy
+		wantErr error
+		want    []int
+		dropped int64
+	}{
+		{`block`, OverflowBlock, context.DeadlineExceeded, []int{1, 2}, 0},
+		{`drop oldest`, OverflowDropOldest, nil, []int{2, 3}, 1},
+		{`drop newest`, OverflowDropNewest, nil, []int{1, 2}, 1},
+		{`error`, OverflowError, ErrQueueFull, []int{1, 2}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQueue[int](QueueCapacity(2), QueueOverflow(tt.policy))
+			require.NoError(t, q.PushCtx(context.Background(), 1))
+			require.NoError(t, q.PushCtx(context.Background(), 2))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			err := q.PushCtx(ctx, 3)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+
+			q.Close()
+			require.Equal(t, tt.want, drain(q))
+			require.Equal(t, tt.dropped, q.Dropped())
+		})
+	}
+}
+
+func TestQueueClosed(t *testing.T) {
+	q := NewQueue[int](QueueCapacity(1))
+	q.Push(1)
+
+	blocked := make(chan error)
+	go func() { blocked <- q.PushCtx(context.Background(), 2) }()
+
+	q.Close()
+	q.Close()
+	require.ErrorIs(t, <-blocked, ErrQueueClosed)
+	require.NotPanics(t, func() { q.Push(3) })
+	require.ErrorIs(t, q.PushCtx(context.Background(), 3), ErrQueueClosed)
+
+	v, err := q.PopCtx(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	_, err = q.PopCtx(context.Background())
+	require.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestQueuePopCtx(t *testing.T) {
+	q := NewQueue[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := q.PopCtx(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWithBuffer(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+
+	require.Equal(t, []int{2, 4, 6, 8, 10}, Map(S(s), Multiply(2), WithBuffer(1)).Slice())
+	require.Equal(t, []int{1, 2}, TakeWhile(S(s), func(x int) bool { return x < 3 }, WithBuffer(1)).Slice())
+	require.Equal(t, []int{3, 4, 5}, DropWhile(S(s), func(x int) bool { return x < 3 }, WithBuffer(1)).Slice())
+	require.Equal(t, []int{1, 2, 3, 4, 5, 1}, ConcatOpts([]Iterator[int]{S(s), Of(1)}, WithBuffer(1)).Slice())
+	require.Equal(t, []int{1, 2}, Sorted(M(map[int]bool{1: true, 2: false}, WithBuffer(1)).Keys()).Slice())
+	require.Len(t, M(map[int]bool{1: true, 2: false}, WithBuffer(1)).Items().Slice(), 2)
+}
@@ -0,0 +1,188 @@
+package iter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket allow bursts up to n and refill n tokens per duration
+// nil bucket means no limit
+type tokenBucket struct {
+	mu       sync.Mutex
+	clock    Clock
+	capacity float64
+	tokens   float64
+	interval time.Duration // time to refill a token
+	last     time.Time
+}
+
+func newTokenBucket(clock Clock, n int, per time.Duration) *tokenBucket {
+	if n <= 0 || per <= 0 {
+		return nil
+	}
+
+	return &tokenBucket{
+		clock:    clock,
+		capacity: float64(n),
+		tokens:   float64(n),
+		interval: per / time.Duration(n),
+		last:     clock.Now(),
+	}
+}
+
+// Wait block until a token is available and take it
+func (b *tokenBucket) Wait() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for {
+		now := b.clock.Now()
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			return
+		}
+
+		<-b.clock.After(time.Duration((1 - b.tokens) * float64(b.interval)))
+	}
+}
+
+// RateLimit delay elements so that at most n elements are emitted per duration, bursts up to n are allowed
+func RateLimit[T any](it Iterator[T], n int, per time.Duration, opts ...Option) Iterator[T] {
+	bucket := newTokenBucket(newOptions(opts...).clock, n, per)
+
+	return &withNext[T]{
+		name:    "rateLimit",
+		parents: []any{it},
+		next: func() (T, bool) {
+			v, ok := it.Next()
+			if ok {
+				bucket.Wait()
+			}
+			return v, ok
+		},
+		close: func() { Close(it) },
+	}
+}
+
+// Throttle emit an element and drop following elements pulled within interval
+func Throttle[T any](it Iterator[T], interval time.Duration, opts ...Option) Iterator[T] {
+	clock := newOptions(opts...).clock
+	var last time.Time
+	first := true
+
+	return &withNext[T]{
+		name:    "throttle",
+		parents: []any{it},
+		next: func() (r T, ok bool) {
+			for v, ok := it.Next(); ok; v, ok = it.Next() {
+				now := clock.Now()
+				if first || now.Sub(last) >= interval {
+					first = false
+					last = now
+					return v, true
+				}
+			}
+			return r, false
+		},
+		close: func() { Close(it) },
+	}
+}
+
+// Debounce emit an element only when no other element arrives within d after it
+// the pending element is emitted when upstream ends
+func Debounce[T any](it Iterator[T], d time.Duration, opts ...Option) Iterator[T] {
+	clock := newOptions(opts...).clock
+	ctx, cancel := context.WithCancel(context.Background())
+	var src <-chan T
+	o := sync.Once{}
+
+	return &withNext[T]{
+		name:    "debounce",
+		parents: []any{it},
+		next: func() (r T, ok bool) {
+			o.Do(func() { src = pump(ctx, it) })
+
+			v, ok := <-src
+			if !ok {
+				return r, false
+			}
+
+			for {
+				select {
+				case nv, ok := <-src:
+					if !ok {
+						return v, true
+					}
+					v = nv
+				case <-clock.After(d):
+					return v, true
+				}
+			}
+		},
+		close: func() {
+			cancel()
+			Close(it)
+		},
+	}
+}
+
+// Sample emit the latest element at every d, elements between ticks are dropped
+// the pending element is emitted when upstream ends
+func Sample[T any](it Iterator[T], d time.Duration, opts ...Option) Iterator[T] {
+	clock := newOptions(opts...).clock
+	ctx, cancel := context.WithCancel(context.Background())
+	var src <-chan T
+	var tick <-chan time.Time
+	var pending T
+	hasPending := false
+	o := sync.Once{}
+
+	return &withNext[T]{
+		name:    "sample",
+		parents: []any{it},
+		next: func() (r T, ok bool) {
+			o.Do(func() {
+				src = pump(ctx, it)
+				tick = clock.After(d)
+			})
+
+			for src != nil {
+				select {
+				case v, ok := <-src:
+					if !ok {
+						src = nil
+						continue
+					}
+					pending, hasPending = v, true
+				case <-tick:
+					tick = clock.After(d)
+					if hasPending {
+						hasPending = false
+						return pending, true
+					}
+				}
+			}
+
+			if hasPending {
+				hasPending = false
+				return pending, true
+			}
+			return r, false
+		},
+		close: func() {
+			cancel()
+			Close(it)
+		},
+	}
+}
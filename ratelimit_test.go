@@ -0,0 +1,107 @@
+package iter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	type args struct {
+		n   int
+		per time.Duration
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []time.Duration
+	}{
+		{`burst`, args{2, time.Second}, []time.Duration{0, 0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond}},
+		{`single`, args{1, time.Second}, []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}},
+		{`unlimited`, args{0, time.Second}, []time.Duration{0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := newFakeClock(true)
+			start := clk.Now()
+
+			it := RateLimit(Of(1, 2, 3, 4, 5), tt.args.n, tt.args.per, WithClock(clk))
+			got := []time.Duration{}
+			for _, ok := it.Next(); ok; _, ok = it.Next() {
+				got = append(got, clk.Now().Sub(start))
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMapWithRateLimit(t *testing.T) {
+	clk := newFakeClock(true)
+	start := clk.Now()
+
+	got := Map(Of(1, 2, 3, 4, 5), Multiply(2), WithRateLimit(2, time.Second), WithClock(clk)).Slice()
+	require.Equal(t, []int{2, 4, 6, 8, 10}, got)
+	require.Equal(t, 1500*time.Millisecond, clk.Now().Sub(start))
+}
+
+func TestThrottle(t *testing.T) {
+	clk := newFakeClock(false)
+	it := Of(1, 2, 3, 4, 5, 6).Filter(func(int) bool {
+		clk.Advance(400 * time.Millisecond)
+		return true
+	})
+
+	got := Throttle(it, time.Second, WithClock(clk)).Slice()
+	require.Equal(t, []int{1, 4}, got)
+}
+
+func TestDebounce(t *testing.T) {
+	clk := newFakeClock(false)
+	in := make(chan int)
+	done := make(chan []int)
+	go func() { done <- Debounce(C(in), time.Second, WithClock(clk)).Slice() }()
+
+	in <- 1
+	in <- 2
+	clk.WaitCalls(2) // waiting on 2
+	clk.Advance(time.Second)
+
+	in <- 3
+	close(in)
+
+	require.Equal(t, []int{2, 3}, <-done)
+}
+
+func TestSample(t *testing.T) {
+	clk := newFakeClock(false)
+	in := make(chan int)
+	entered := make(chan struct{})
+	src := &withNext[int]{
+		next: func() (int, bool) {
+			entered <- struct{}{}
+			v, ok := <-in
+			return v, ok
+		},
+	}
+	done := make(chan []int)
+	go func() { done <- Sample(src, time.Second, WithClock(clk)).Slice() }()
+
+	clk.WaitCalls(1)
+	<-entered
+	in <- 1
+	<-entered
+	in <- 2
+	<-entered // 1, 2 received
+	clk.Advance(time.Second)
+
+	clk.WaitCalls(2)
+	clk.Advance(time.Second) // nothing to emit
+
+	clk.WaitCalls(3)
+	in <- 3
+	<-entered
+	close(in)
+
+	require.Equal(t, []int{2, 3}, <-done)
+}
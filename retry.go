@@ -0,0 +1,140 @@
+package iter
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RetryPolicy describe how MapRetry() retries failed mapper calls
+type RetryPolicy struct {
+	MaxAttempts    int              // attempts including the first one, less than 1 means 1
+	InitialBackoff time.Duration    // delay before the first retry
+	MaxBackoff     time.Duration    // upper bound of the delay, 0 means no bound
+	Multiplier     float64          // backoff growth factor, less than 1 means 2
+	Jitter         float64          // randomize delay down to (1-Jitter)*delay, 0 ~ 1
+	Timeout        time.Duration    // per attempt timeout passed via context, 0 means no timeout
+	Retryable      func(error) bool // nil means all errors are retryable
+}
+
+// maxDelay is the largest float64 below 2^63, so it converts to time.Duration without overflow
+const maxDelay = float64(math.MaxInt64 - 1023)
+
+// Backoff return delay before the next attempt after attempt-th failure
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if math.IsNaN(delay) { // 0 * Inf
+		delay = 0
+	}
+	delay = min(delay, maxDelay)
+
+	if p.Jitter > 0 {
+		delay -= delay * min(p.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) retryable(err error) bool { return p.Retryable == nil || p.Retryable(err) }
+
+// Failure is an element that mapper failed to process
+type Failure[T any] struct {
+	Value    T
+	Err      error
+	Attempts int
+}
+
+// DeadLetter collect failed elements of MapRetry(), use Add as its callback
+type DeadLetter[T any] struct {
+	mu    sync.Mutex
+	items []Failure[T]
+}
+
+func NewDeadLetter[T any]() *DeadLetter[T] { return &DeadLetter[T]{} }
+
+func (d *DeadLetter[T]) Add(f Failure[T]) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = append(d.items, f)
+}
+
+func (d *DeadLetter[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.items)
+}
+
+// Iter return failed elements collected so far
+func (d *DeadLetter[T]) Iter() Iterator[Failure[T]] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return S(append([]Failure[T](nil), d.items...))
+}
+
+type retryResult[T1, T2 any] struct {
+	out     T2
+	failure *Failure[T1]
+}
+
+// MapRetry map with mapper concurrently like Map() and retry failed calls by policy
+// mapper should honor the context to respect RetryPolicy.Timeout
+// elements that still fail are passed to deadLetter in order instead of being emitted, nil deadLetter drops them
+func MapRetry[T1, T2 any](it Iterator[T1], mapper func(context.Context, T1) (T2, error), policy RetryPolicy, deadLetter func(Failure[T1]), opts ...Option) Iterator[T2] {
+	clock := newOptions(opts...).clock
+	attempts := max(policy.MaxAttempts, 1)
+
+	call := func(v T1) (T2, error) {
+		ctx := context.Background()
+		if policy.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+			defer cancel()
+		}
+		return mapper(ctx, v)
+	}
+
+	results := Map(it, func(v T1) (r retryResult[T1, T2]) {
+		for attempt := 1; ; attempt++ {
+			out, err := call(v)
+			if err == nil {
+				r.out = out
+				return r
+			}
+
+			if attempt >= attempts || !policy.retryable(err) {
+				r.failure = &Failure[T1]{Value: v, Err: err, Attempts: attempt}
+				return r
+			}
+
+			<-clock.After(policy.Backoff(attempt))
+		}
+	}, opts...)
+
+	return &withNext[T2]{
+		name:    "mapRetry",
+		parents: []any{results},
+		next: func() (r T2, ok bool) {
+			for v, ok := results.Next(); ok; v, ok = results.Next() {
+				if v.failure == nil {
+					return v.out, true
+				}
+
+				if deadLetter != nil {
+					deadLetter(*v.failure)
+				}
+			}
+			return r, false
+		},
+		close: func() { Close(results) },
+	}
+}
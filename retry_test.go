@@ -0,0 +1,103 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := [...]struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, policy.Backoff(tt.attempt), "attempt=%d", tt.attempt)
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(3)
+		require.GreaterOrEqual(t, got, 200*time.Millisecond)
+		require.LessOrEqual(t, got, 400*time.Millisecond)
+	}
+
+	// unbounded delay saturates instead of overflowing
+	unbounded := RetryPolicy{InitialBackoff: time.Second}
+	for _, attempt := range []int{64, 100, 10000} {
+		require.Greater(t, unbounded.Backoff(attempt), time.Duration(math.MaxInt64/2), "attempt=%d", attempt)
+	}
+	require.Zero(t, RetryPolicy{}.Backoff(10000), "0 * Inf")
+}
+
+func TestMapRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	var mu sync.Mutex
+	calls := map[int]int{}
+	mapper := func(ctx context.Context, x int) (int, error) {
+		mu.Lock()
+		calls[x]++
+		n := calls[x]
+		mu.Unlock()
+
+		switch {
+		case x == 3:
+			return 0, errTemporary
+		case x == 4:
+			return 0, errPermanent
+		case n < 3:
+			return 0, errTemporary
+		}
+		return x * 2, nil
+	}
+
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		Retryable:      func(err error) bool { return !errors.Is(err, errPermanent) },
+	}
+	dead := NewDeadLetter[int]()
+	got := MapRetry(Of(1, 2, 3, 4, 5), mapper, policy, dead.Add, WithClock(newFakeClock(true))).Slice()
+
+	require.Equal(t, []int{2, 4, 10}, got)
+	require.Equal(t, []Failure[int]{
+		{Value: 3, Err: errTemporary, Attempts: 3},
+		{Value: 4, Err: errPermanent, Attempts: 1},
+	}, dead.Iter().Slice())
+	require.Equal(t, map[int]int{1: 3, 2: 3, 3: 3, 4: 1, 5: 3}, calls)
+}
+
+func TestMapRetryTimeout(t *testing.T) {
+	mapper := func(ctx context.Context, x int) (int, error) {
+		if x%2 == 0 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return x, nil
+	}
+
+	var failed []int
+	policy := RetryPolicy{MaxAttempts: 2, Timeout: 10 * time.Millisecond}
+	got := MapRetry(Of(1, 2, 3, 4), mapper, policy, func(f Failure[int]) {
+		require.ErrorIs(t, f.Err, context.DeadlineExceeded)
+		failed = append(failed, f.Value)
+	}).Slice()
+
+	require.Equal(t, []int{1, 3}, got)
+	require.Equal(t, []int{2, 4}, failed)
+}
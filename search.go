@@ -0,0 +1,94 @@
+package iter
+
+// Any return true if any element satisfies pred, it stops at the first match and closes it
+func Any[T any](it Iterator[T], pred func(T) bool) bool {
+	_, ok := Find(it, pred)
+	return ok
+}
+
+// All return true if all elements satisfy pred, it stops at the first mismatch and closes it
+func All[T any](it Iterator[T], pred func(T) bool) bool {
+	return !Any(it, func(v T) bool { return !pred(v) })
+}
+
+// None return true if no element satisfies pred, it stops at the first match and closes it
+func None[T any](it Iterator[T], pred func(T) bool) bool { return !Any(it, pred) }
+
+// Find return the first element satisfying pred and close it
+func Find[T any](it Iterator[T], pred func(T) bool) (r T, ok bool) {
+	_, r, ok = find(it, pred)
+	return r, ok
+}
+
+// FindIndex return index of the first element satisfying pred or -1, and close it
+func FindIndex[T any](it Iterator[T], pred func(T) bool) int {
+	i, _, _ := find(it, pred)
+	return i
+}
+
+func find[T any](it Iterator[T], pred func(T) bool) (i int, r T, ok bool) {
+	defer Close(it)
+
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		if pred(v) {
+			return i, v, true
+		}
+		i++
+	}
+	return -1, r, false
+}
+
+// Contains return true if it has x, it stops at the first match and closes it
+func Contains[T comparable](it Iterator[T], x T) bool {
+	return Any(it, func(v T) bool { return v == x })
+}
+
+// Count return number of elements satisfying pred
+func Count[T any](it Iterator[T], pred func(T) bool) (n int) {
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// Nth return n-th element from 0 and close it
+func Nth[T any](it Iterator[T], n int) (r T, ok bool) {
+	if n < 0 {
+		Close(it)
+		return r, false
+	}
+
+	i := 0
+	return Find(it, func(T) bool {
+		i++
+		return i > n
+	})
+}
+
+// Equal return true if a and b have the same elements in the same order
+func Equal[T comparable](a, b Iterator[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc return true if a and b have the same length and eq holds for each pair of elements
+// it stops at the first difference and closes both
+func EqualFunc[T1, T2 any](a Iterator[T1], b Iterator[T2], eq func(T1, T2) bool) bool {
+	defer Close(a)
+	defer Close(b)
+
+	for {
+		va, okA := a.Next()
+		vb, okB := b.Next()
+		if okA != okB {
+			return false
+		}
+		if !okA {
+			return true
+		}
+		if !eq(va, vb) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package iter
+
+import (
+	"math"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	s := []int{1, 3, 4, 5, 6}
+	gt := func(n int) func(int) bool { return func(x int) bool { return x > n } }
+
+	require.True(t, Any(S(s), Even[int]))
+	require.False(t, Any(S(s), gt(10)))
+	require.False(t, Any(Of[int](), gt(0)))
+
+	require.True(t, All(S(s), gt(0)))
+	require.False(t, All(S(s), Odd[int]))
+	require.True(t, All(Of[int](), gt(0)))
+
+	require.True(t, None(S(s), gt(10)))
+	require.False(t, None(S(s), Even[int]))
+
+	v, ok := Find(S(s), Even[int])
+	require.True(t, ok)
+	require.Equal(t, 4, v)
+	_, ok = Find(S(s), gt(10))
+	require.False(t, ok)
+
+	require.Equal(t, 2, FindIndex(S(s), Even[int]))
+	require.Equal(t, -1, FindIndex(S(s), gt(10)))
+
+	require.True(t, Contains(S(s), 5))
+	require.False(t, Contains(S(s), 2))
+
+	require.Equal(t, 2, Count(S(s), Even[int]))
+}
+
+func TestNth(t *testing.T) {
+	tests := [...]struct {
+		n    int
+		want int
+		ok   bool
+	}{
+		{0, 10, true},
+		{2, 30, true},
+		{3, 0, false},
+		{-1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n), func(t *testing.T) {
+			v, ok := Nth(Of(10, 20, 30), tt.n)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	require.True(t, Equal(Of(1, 2, 3), Range(1, 4)))
+	require.True(t, Equal(Of[int](), Of[int]()))
+	require.False(t, Equal(Of(1, 2), Of(1, 2, 3)))
+	require.False(t, Equal(Of(1, 2, 3), Of(1, 2)))
+	require.False(t, Equal(Of(1, 2, 3), Of(1, 5, 3)))
+
+	require.True(t, EqualFunc(Of(1, 2), Of("1", "2"), func(a int, b string) bool { return strconv.Itoa(a) == b }))
+}
+
+func TestSearchShortCircuit(t *testing.T) {
+	pulled := 0
+	require.True(t, Any(counter(Range(0, 100), &pulled), func(x int) bool { return x == 3 }))
+	require.Equal(t, 4, pulled)
+
+	pulled = 0
+	require.False(t, All(counter(Range(0, 100), &pulled), func(x int) bool { return x < 3 }))
+	require.Equal(t, 4, pulled)
+
+	pulled = 0
+	require.False(t, Equal(counter(Range(0, 100), &pulled), Of(0, 1, 5)))
+	require.Equal(t, 3, pulled)
+}
+
+func TestSearchReleaseUpstream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	it := Range(0, math.MaxInt).Map(Multiply(2)).Filter(Even[int]).Skip(1)
+	require.True(t, Contains(it, 100))
+	waitGoroutines(t, before)
+
+	v, ok := Nth(Range(0, math.MaxInt).TakeWhile(func(int) bool { return true }).Map(Multiply(3)), 10)
+	require.True(t, ok)
+	require.Equal(t, 30, v)
+	waitGoroutines(t, before)
+
+	require.True(t, Any(M(map[int]int{1: 1, 2: 2, 3: 3}).Items(), func(Item[int, int]) bool { return true }))
+	waitGoroutines(t, before)
+}
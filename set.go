@@ -0,0 +1,132 @@
+package iter
+
+// Union emit distinct elements of its in the order they are first seen
+func Union[T comparable](its ...Iterator[T]) Iterator[T] {
+	return describe(distinct(Concat(its...)), "union", ups(its)...)
+}
+
+// Intersect emit distinct elements of a which are also in b, b is loaded into memory
+func Intersect[T comparable](a, b Iterator[T]) Iterator[T] {
+	return describe(lazy(func() Iterator[T] {
+		set := toSet(b)
+		return distinct(filter(a, func(v T) bool { _, ok := set[v]; return ok }))
+	}), "intersect", a, b)
+}
+
+// Except emit distinct elements of a which are not in b, b is loaded into memory
+func Except[T comparable](a, b Iterator[T]) Iterator[T] {
+	return describe(lazy(func() Iterator[T] {
+		set := toSet(b)
+		return distinct(filter(a, func(v T) bool { _, ok := set[v]; return !ok }))
+	}), "except", a, b)
+}
+
+// SymmetricDiff emit distinct elements which are in only one of a and b, elements of a come first
+func SymmetricDiff[T comparable](a, b Iterator[T]) Iterator[T] {
+	return describe(lazy(func() Iterator[T] {
+		bs := slice(b)
+		setB := toSet(S(bs))
+		setA := map[T]struct{}{}
+		onlyA := filter(a, func(v T) bool {
+			setA[v] = struct{}{}
+			_, ok := setB[v]
+			return !ok
+		})
+
+		// setA is complete when a is drained
+		onlyB := filter(S(bs), func(v T) bool { _, ok := setA[v]; return !ok })
+		return distinct(Concat(onlyA, onlyB))
+	}), "symmetricDiff", a, b)
+}
+
+func toSet[T comparable](it Iterator[T]) map[T]struct{} {
+	set := make(map[T]struct{}, sizeOf(it).capacity())
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// distinct drop elements already emitted
+func distinct[T comparable](it Iterator[T]) Iterator[T] {
+	seen := map[T]struct{}{}
+	return filter(it, func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+		return true
+	})
+}
+
+// UnionSorted is Union() of a and b sorted by cmp, the result is sorted and uses constant memory
+func UnionSorted[T any](a, b Iterator[T], cmp Less[T]) Iterator[T] {
+	return describe(mergeSorted(a, b, cmp, func(inA, inB bool) bool { return true }), "unionSorted", a, b)
+}
+
+// IntersectSorted is Intersect() of a and b sorted by cmp, the result is sorted and uses constant memory
+func IntersectSorted[T any](a, b Iterator[T], cmp Less[T]) Iterator[T] {
+	return describe(mergeSorted(a, b, cmp, func(inA, inB bool) bool { return inA && inB }), "intersectSorted", a, b)
+}
+
+// ExceptSorted is Except() of a and b sorted by cmp, the result is sorted and uses constant memory
+func ExceptSorted[T any](a, b Iterator[T], cmp Less[T]) Iterator[T] {
+	return describe(mergeSorted(a, b, cmp, func(inA, inB bool) bool { return inA && !inB }), "exceptSorted", a, b)
+}
+
+// SymmetricDiffSorted is SymmetricDiff() of a and b sorted by cmp, the result is sorted and uses constant memory
+func SymmetricDiffSorted[T any](a, b Iterator[T], cmp Less[T]) Iterator[T] {
+	return describe(mergeSorted(a, b, cmp, func(inA, inB bool) bool { return inA != inB }), "symmetricDiffSorted", a, b)
+}
+
+// mergeSorted walk a and b sorted by cmp together and emit distinct elements selected by membership
+// it ends as soon as the remaining elements of the other source can not be selected
+func mergeSorted[T any](a, b Iterator[T], cmp Less[T], emit func(inA, inB bool) bool) Iterator[T] {
+	pa, pb := Peekable(a), Peekable(b)
+	skip := func(p PeekableIterator[T], v T) {
+		p.NextWhile(func(x T) bool { return cmp(x, v) == 0 })
+	}
+	onlyA, onlyB := emit(true, false), emit(false, true)
+	closeBoth := func() {
+		Close[T](pa)
+		Close[T](pb)
+	}
+	done := false
+
+	return &withNext[T]{
+		next: func() (r T, ok bool) {
+			for !done {
+				va, okA := pa.Peek()
+				vb, okB := pb.Peek()
+				if (!okA && !okB) || (!okA && !onlyB) || (!okB && !onlyA) {
+					done = true
+					closeBoth()
+					break
+				}
+
+				var v T
+				var inA, inB bool
+				switch {
+				case !okB:
+					v, inA = va, true
+				case !okA:
+					v, inB = vb, true
+				default:
+					c := cmp(va, vb)
+					v, inA, inB = va, c <= 0, c >= 0
+					if c > 0 {
+						v = vb
+					}
+				}
+
+				skip(pa, v)
+				skip(pb, v)
+				if emit(inA, inB) {
+					return v, true
+				}
+			}
+			return r, false
+		},
+		close: closeBoth,
+	}
+}
@@ -0,0 +1,78 @@
+package iter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	a := []int{5, 1, 3, 3, 7, 1}
+	b := []int{3, 4, 5, 4}
+
+	require.Equal(t, []int{5, 1, 3, 7, 4}, Union(S(a), S(b)).Slice())
+	require.Equal(t, []int{5, 3}, Intersect(S(a), S(b)).Slice())
+	require.Equal(t, []int{1, 7}, Except(S(a), S(b)).Slice())
+	require.Equal(t, []int{1, 7, 4}, SymmetricDiff(S(a), S(b)).Slice())
+	require.Equal(t, []int{1, 6, 4}, SymmetricDiff(Of(1, 2), Of(6, 2, 4, 6)).Slice())
+
+	require.Empty(t, Union[int]().Slice())
+	require.Empty(t, Intersect(S(a), Of[int]()).Slice())
+	require.Equal(t, []int{5, 1, 3, 7}, Except(S(a), Of[int]()).Slice())
+}
+
+func TestSetLazy(t *testing.T) {
+	pulled := 0
+	it := Intersect(Of(1, 2), counter(Of(2, 3), &pulled))
+	require.Equal(t, 0, pulled)
+	require.Equal(t, []int{2}, it.Slice())
+	require.Equal(t, 2, pulled)
+}
+
+func TestSetSorted(t *testing.T) {
+	type args struct {
+		a, b []int
+	}
+	tests := [...]struct {
+		name      string
+		args      args
+		union     []int
+		intersect []int
+		except    []int
+		symmetric []int
+	}{
+		{`valid`, args{[]int{1, 1, 3, 5, 7}, []int{1, 2, 3, 3, 8}}, []int{1, 2, 3, 5, 7, 8}, []int{1, 3}, []int{5, 7}, []int{2, 5, 7, 8}},
+		{`empty a`, args{nil, []int{1, 2}}, []int{1, 2}, nil, nil, []int{1, 2}},
+		{`empty b`, args{[]int{1, 1, 2}, nil}, []int{1, 2}, nil, []int{1, 2}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.args.a, tt.args.b
+			require.Equal(t, tt.union, UnionSorted(S(a), S(b), Asending[int]).Slice())
+			require.Equal(t, tt.intersect, IntersectSorted(S(a), S(b), Asending[int]).Slice())
+			require.Equal(t, tt.except, ExceptSorted(S(a), S(b), Asending[int]).Slice())
+			require.Equal(t, tt.symmetric, SymmetricDiffSorted(S(a), S(b), Asending[int]).Slice())
+		})
+	}
+}
+
+func TestSetSortedStreaming(t *testing.T) {
+	pulled := 0
+	it := IntersectSorted(counter(Range(0, 1000), &pulled), Of(1, 2), Asending[int])
+	require.Equal(t, []int{1, 2}, it.Slice())
+	require.Equal(t, 4, pulled, "stop when b ends")
+
+	pulled = 0
+	it = ExceptSorted(Of(1, 3), counter(Range(0, 1000), &pulled), Asending[int])
+	require.Empty(t, it.Slice())
+	require.Equal(t, 5, pulled, "stop when a ends")
+
+	require.Equal(t, []int{1, 2}, IntersectSorted(Range(0, math.MaxInt), Of(1, 2), Asending[int]).Slice())
+	require.Equal(t, []int{-1, 1}, ExceptSorted(Of(-1, 1), Range(0, math.MaxInt, 2), Asending[int]).Slice())
+
+	pulled = 0
+	v, _ := UnionSorted(counter(Range(0, 1000), &pulled), Of(0), Asending[int]).Next()
+	require.Equal(t, 0, v)
+	require.Equal(t, 2, pulled, "pull only what is needed to decide")
+}
@@ -0,0 +1,59 @@
+package iter
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Sink call fn for each element in order and stop at the first error
+// fn is called synchronously, so slow consumer slows down the upstream
+// it is closed when fn fails
+func Sink[T any](it Iterator[T], fn func(T) error) error {
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		if err := fn(v); err != nil {
+			Close(it)
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteLines write each element to w as a line formatted with format, "%v" if empty
+func WriteLines[T any](w io.Writer, it Iterator[T], format string) error {
+	if format == "" {
+		format = "%v"
+	}
+	format += "\n"
+
+	return Sink(it, func(v T) error {
+		_, err := fmt.Fprintf(w, format, v)
+		return err
+	})
+}
+
+// SendTo send each element to ch until it ends or ctx is done
+// ch is not closed, the caller owns it
+func SendTo[T any](ctx context.Context, ch chan<- T, it Iterator[T]) error {
+	return Sink(it, func(v T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ch <- v:
+			return nil
+		}
+	})
+}
+
+// SinkBatches call fn with batches of up to size elements for bulk writes
+// the last partial batch is flushed when it ends and the first error stops the sink
+func SinkBatches[T any](it Iterator[T], size int, fn func([]T) error) error {
+	if size < 1 {
+		size = 1
+	}
+	return Sink(Chunk(it, size), fn)
+}
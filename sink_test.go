@@ -0,0 +1,110 @@
+package iter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLines(t *testing.T) {
+	type args struct {
+		s      []int
+		format string
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want string
+	}{
+		{`default`, args{[]int{1, 2, 3}, ""}, "1\n2\n3\n"},
+		{`format`, args{[]int{1, 2, 3}, "%03d"}, "001\n002\n003\n"},
+		{`empty`, args{nil, ""}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteLines(&buf, S(tt.args.s), tt.args.format))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestSendTo(t *testing.T) {
+	ch := make(chan int, 10)
+	require.NoError(t, SendTo(context.Background(), ch, Of(1, 2, 3)))
+	close(ch)
+	require.Equal(t, []int{1, 2, 3}, C(ch).Slice())
+}
+
+func TestSendToCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int, 1)
+
+	it := Of(1, 2, 3).Filter(func(x int) bool {
+		if x == 2 {
+			cancel()
+		}
+		return true
+	})
+	err := SendTo(ctx, ch, it)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, <-ch)
+}
+
+func TestSinkBatches(t *testing.T) {
+	type args struct {
+		s    []int
+		size int
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want [][]int
+	}{
+		{`flush last`, args{[]int{1, 2, 3, 4, 5}, 2}, [][]int{{1, 2}, {3, 4}, {5}}},
+		{`exact`, args{[]int{1, 2, 3, 4}, 2}, [][]int{{1, 2}, {3, 4}}},
+		{`empty`, args{nil, 2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]int
+			err := SinkBatches(S(tt.args.s), tt.args.size, func(batch []int) error {
+				got = append(got, batch)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSinkBatchesError(t *testing.T) {
+	errFail := errors.New("fail")
+	calls := 0
+	err := SinkBatches(Of(1, 2, 3, 4, 5), 2, func(batch []int) error {
+		calls++
+		return errFail
+	})
+	require.ErrorIs(t, err, errFail)
+	require.Equal(t, 1, calls)
+}
+
+func TestSinkErrorClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	errFail := errors.New("fail")
+	it := Map(Range(0, math.MaxInt), func(x int) int { return x * 2 }, WithConcurrency(4))
+	err := Sink(it, func(x int) error {
+		if x == 10 {
+			return errFail
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errFail)
+	waitGoroutines(t, before)
+}
@@ -0,0 +1,116 @@
+package iter
+
+import "math"
+
+// Sizer is implemented by iterators which know bounds of number of remaining elements
+// max < 0 means no upper bound is known, exact means min == max is the number of remaining elements
+type Sizer interface {
+	SizeHint() (min, max int, exact bool)
+}
+
+// SizeHint return bounds of number of remaining elements of it, (0, -1, false) if it is not a Sizer
+func SizeHint[T any](it Iterator[T]) (min, max int, exact bool) {
+	b := sizeOf(it)
+	return b.min, b.max, b.exact
+}
+
+// maxPrealloc limit capacity preallocated from size hints
+const maxPrealloc = 1 << 20
+
+type bounds struct {
+	min, max int
+	exact    bool
+}
+
+var unknownSize = bounds{0, -1, false}
+
+func exactSize(n int) bounds { return bounds{n, n, true} }
+
+func sizeOf[T any](it Iterator[T]) bounds {
+	if s, ok := it.(Sizer); ok {
+		min, max, exact := s.SizeHint()
+		return bounds{min, max, exact}
+	}
+	return unknownSize
+}
+
+func (it *withNext[T]) SizeHint() (min, max int, exact bool) {
+	if it.hint == nil {
+		return unknownSize.min, unknownSize.max, unknownSize.exact
+	}
+	b := it.hint()
+	return b.min, b.max, b.exact
+}
+
+// sub return bounds after n elements are pulled
+func (b bounds) sub(n int) bounds {
+	n = max(n, 0)
+	b.min = max(b.min-n, 0)
+	if b.max >= 0 {
+		b.max = max(b.max-n, 0)
+	}
+	return b
+}
+
+// atMost return bounds of operators which may drop elements
+func (b bounds) atMost() bounds { return bounds{0, b.max, b.max == 0} }
+
+// limit return bounds of at most n elements
+func (b bounds) limit(n int) bounds {
+	n = max(n, 0)
+	if b.max < 0 || b.max > n {
+		b.max = n
+	}
+	b.min = min(b.min, n)
+	b.exact = b.min == b.max
+	return b
+}
+
+func (b bounds) add(o bounds) bounds {
+	r := bounds{min: b.min + o.min, max: b.max + o.max, exact: b.exact && o.exact}
+	if r.min < 0 {
+		r.min = math.MaxInt
+		r.exact = false
+	}
+	if b.max < 0 || o.max < 0 || r.max < 0 {
+		r.max = -1
+		r.exact = false
+	}
+	return r
+}
+
+// chunks return bounds of chunks of size
+func (b bounds) chunks(size int) bounds {
+	if size <= 0 {
+		return exactSize(0)
+	}
+
+	ceil := func(n int) int { return n/size + min(n%size, 1) }
+	b.min = ceil(b.min)
+	if b.max >= 0 {
+		b.max = ceil(b.max)
+	}
+	return b
+}
+
+// capacity return capacity to preallocate
+func (b bounds) capacity() int { return min(b.min, maxPrealloc) }
+
+// countdown return hint for iterator which emits one element for each element of src
+// and may pull src from another goroutine after start() is called, the hint of src is taken at start()
+func countdown[T any](src Iterator[T]) (hint func() bounds, start func(), pulled func()) {
+	var initial *bounds
+	n := 0
+	hint = func() bounds {
+		if initial == nil {
+			return sizeOf(src)
+		}
+		return initial.sub(n)
+	}
+	start = func() {
+		b := sizeOf(src)
+		initial = &b
+	}
+	pulled = func() { n++ }
+	return hint, start, pulled
+}
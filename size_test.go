@@ -0,0 +1,136 @@
+package iter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeHint(t *testing.T) {
+	type want struct {
+		min, max int
+		exact    bool
+	}
+	exact := func(n int) want { return want{n, n, true} }
+	unknown := want{0, -1, false}
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+
+	tests := [...]struct {
+		name string
+		it   func() Iterator[int]
+		want want
+	}{
+		{"slice", func() Iterator[int] { return Of(1, 2, 3) }, exact(3)},
+		{"range", func() Iterator[int] { return Range(0, 10, 3) }, exact(4)},
+		{"range negative", func() Iterator[int] { return Range(10, 0, -2) }, exact(5)},
+		{"range empty", func() Iterator[int] { return Range(10, 0) }, exact(0)},
+		{"range huge", func() Iterator[int] { return Range(math.MinInt, math.MaxInt) }, unknown},
+		{"chan", func() Iterator[int] { return C(ch) }, want{2, -1, false}},
+		{"map keys", func() Iterator[int] { return M(map[int]int{1: 1, 2: 2}).Keys() }, exact(2)},
+		{"map", func() Iterator[int] { return Range(0, 5).Map(Multiply(2)) }, exact(5)},
+		{"sequential map", func() Iterator[int] { return Map(Range(0, 5), Multiply(2), WithSequential()) }, exact(5)},
+		{"filter", func() Iterator[int] { return Range(0, 5).Filter(Even[int]) }, want{0, 5, false}},
+		{"fused map", func() Iterator[int] {
+			return Map(Map(Range(0, 5), Multiply(2), WithSequential()), Multiply(2), WithSequential())
+		}, exact(5)},
+		{"fused filter", func() Iterator[int] {
+			return Map(Range(0, 5).Filter(Even[int]), Multiply(2), WithSequential())
+		}, want{0, 5, false}},
+		{"skip", func() Iterator[int] { return Range(0, 5).Map(Multiply(1)).Skip(2) }, exact(3)},
+		{"skip slice", func() Iterator[int] { return Of(1, 2, 3).Skip(5) }, exact(0)},
+		{"skip negative", func() Iterator[int] { return Of(1, 2, 3).Skip(-2) }, exact(3)},
+		{"skip negative generic", func() Iterator[int] { return Range(0, 3).Map(Multiply(1)).Skip(-2) }, exact(3)},
+		{"take while", func() Iterator[int] { return Range(0, 5).TakeWhile(Even[int]) }, want{0, 5, false}},
+		{"drop while", func() Iterator[int] { return Range(0, 5).DropWhile(Even[int]) }, want{0, 5, false}},
+		{"concat", func() Iterator[int] { return Concat(Of(1, 2), Range(0, 3)) }, exact(5)},
+		{"concat unknown", func() Iterator[int] { return Concat(Of(1, 2), Range(0, 3).Filter(Even[int])) }, want{2, 5, false}},
+		{"concat chan", func() Iterator[int] { return Concat(Of(1, 2), C(ch)) }, want{4, -1, false}},
+		{"sorted", func() Iterator[int] { return Sorted(Of(3, 1, 2)) }, exact(3)},
+		{"reverse", func() Iterator[int] { return Reverse(Range(0, 4)) }, exact(4)},
+		{"last", func() Iterator[int] { return Last(Range(0, 10).Filter(Even[int]), 3) }, want{0, 3, false}},
+		{"peekable", func() Iterator[int] { return Peekable(Of(1, 2, 3)) }, exact(3)},
+		{"flatten", func() Iterator[int] { return FlattenSlice(Of([]int{1}, []int{2})) }, unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max, exact := SizeHint(tt.it())
+			require.Equal(t, tt.want, want{min, max, exact})
+		})
+	}
+}
+
+func TestSizeHintChunk(t *testing.T) {
+	min, max, exact := SizeHint(Chunk(Range(0, 10).Map(Multiply(1)), 3))
+	require.Equal(t, []any{4, 4, true}, []any{min, max, exact})
+
+	min, max, exact = SizeHint(Chunk(Range(0, 10).Filter(Even[int]), 3))
+	require.Equal(t, []any{0, 4, false}, []any{min, max, exact})
+}
+
+func TestSizeHintFloatRange(t *testing.T) {
+	it := Range(0, 1, 0.1)
+	min, max, exact := SizeHint(it)
+	require.False(t, exact)
+
+	n := len(it.Slice())
+	require.LessOrEqual(t, min, n)
+	require.GreaterOrEqual(t, max, n)
+}
+
+func TestSizeHintCountdown(t *testing.T) {
+	tests := [...]struct {
+		name string
+		it   func() Iterator[int]
+	}{
+		{"slice", func() Iterator[int] { return Of(1, 2, 3, 4) }},
+		{"map", func() Iterator[int] { return Range(0, 4).Map(Multiply(2)) }},
+		{"prefetch", func() Iterator[int] { return ConcatOpts([]Iterator[int]{Of(1, 2), Of(3, 4)}, WithBuffer(2)) }},
+		{"skip", func() Iterator[int] { return Range(0, 6).Map(Multiply(1)).Skip(2) }},
+		{"map items", func() Iterator[int] {
+			return Map(M(map[int]int{1: 1, 2: 2, 3: 3, 4: 4}).Items(), func(item Item[int, int]) int { return item.Key })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := tt.it()
+			for n := 4; n >= 0; n-- {
+				min, max, exact := SizeHint(it)
+				require.Equal(t, []any{n, n, true}, []any{min, max, exact})
+				it.Next()
+			}
+		})
+	}
+}
+
+func TestSlicePrealloc(t *testing.T) {
+	s := Range(0, 100).Map(Multiply(2)).Slice()
+	require.Len(t, s, 100)
+	require.Equal(t, 100, cap(s))
+
+	require.Nil(t, Range(0, 0).Map(Multiply(2)).Slice())
+}
+
+func BenchmarkSlicePrealloc(b *testing.B) {
+	benchmarks := [...]struct {
+		name string
+		it   func() Iterator[int]
+	}{
+		{"range", func() Iterator[int] { return Range(0, 1000) }},
+		{"map", func() Iterator[int] { return Map(Range(0, 1000), Multiply(2), WithSequential()) }},
+		{"filter", func() Iterator[int] { return Range(0, 1000).Filter(Even[int]) }},
+		{"sorted", func() Iterator[int] { return Sorted(Range(1000, 0, -1)) }},
+		{"unknown", func() Iterator[int] { return FlattenSlice(Chunk(Range(0, 1000).Map(Multiply(1)), 100)) }},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bm.it().Slice()
+			}
+		})
+	}
+}
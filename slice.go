@@ -2,50 +2,152 @@ package iter
 
 import (
 	"math"
-	"sync"
+
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
 )
 
-func S[S ~[]T, T any](s S) Iterator[T] {
-	index := 0
-	return &withNext[T]{
-		next: func() (r T, ok bool) {
-			if index >= len(s) {
-				return r, false
-			}
+// SliceIterator iterate slice with random access to the remaining elements
+// Reverse(), Skip(), Chunk(), Last() and Slice() use it to avoid pulling and copying elements
+type SliceIterator[T any] interface {
+	Reusable[T]
 
-			index++
-			return s[index-1], true
-		},
+	Len() int                           // number of remaining elements
+	At(i int) T                         // i-th remaining element
+	Remaining() []T                     // remaining elements, sharing the slice
+	Back() (T, bool)                    // take the last remaining element
+	SubSlice(i, j int) SliceIterator[T] // new iterator of remaining elements from i to j(exclusive), sharing the slice
+}
+
+type sliceIter[T any] struct {
+	*reusable[T]
+	s           []T
+	front, back int
+}
+
+// S iterate slice, the iterator is a Reusable SliceIterator
+func S[S ~[]T, T any](s S) Iterator[T] { return newSliceIter([]T(s)) }
+
+func newSliceIter[T any](s []T) *sliceIter[T] {
+	it := &sliceIter[T]{s: s, back: len(s)}
+	it.reusable = &reusable[T]{
+		withNext: &withNext[T]{name: "slice", next: it.pop, hint: func() bounds { return exactSize(it.Len()) }},
+		reset:    func() { it.front, it.back = 0, len(s) },
+	}
+	return it
+}
+
+func (it *sliceIter[T]) pop() (r T, ok bool) {
+	if it.front >= it.back {
+		return r, false
+	}
+
+	it.front++
+	return it.s[it.front-1], true
+}
+
+func (it *sliceIter[T]) Back() (r T, ok bool) {
+	if it.front >= it.back {
+		return r, false
 	}
+
+	it.back--
+	return it.s[it.back], true
+}
+
+func (it *sliceIter[T]) Len() int       { return it.back - it.front }
+func (it *sliceIter[T]) At(i int) T     { return it.Remaining()[i] }
+func (it *sliceIter[T]) Remaining() []T { return it.s[it.front:it.back:it.back] }
+func (it *sliceIter[T]) SubSlice(i, j int) SliceIterator[T] {
+	return newSliceIter(it.Remaining()[i:j:j])
 }
+func (it *sliceIter[T]) Skip(n int) Iterator[T] { return skip[T](it, n) }
+func (it *sliceIter[T]) Slice() []T             { return slice[T](it) }
+
 func Of[T any](s ...T) Iterator[T] { return S(s) }
 
-func Reverse[T any](it Iterator[T]) Iterator[T] {
-	index := math.MinInt
-	var s []T
-	o := sync.Once{}
-
-	return &withNext[T]{
-		next: func() (r T, ok bool) {
-			if s != nil && index <= 0 {
-				return r, false
-			}
+// Range iterate from start to stop(exclusive) by step, default step is 1, the iterator is Reusable
+func Range[T constraints.Integer | constraints.Float](start, stop T, step ...T) Iterator[T] {
+	var by T = 1
+	for _, e := range step {
+		by = e
+	}
 
-			o.Do(func() {
-				s = slice(it)
-				index = len(s)
-			})
+	v, end := start, false
+	done := func() bool { return end || by == 0 || (by > 0 && v >= stop) || (by < 0 && v <= stop) }
+	return &reusable[T]{
+		withNext: &withNext[T]{
+			name: "range",
+			next: func() (r T, ok bool) {
+				if done() {
+					return r, false
+				}
 
-			index--
-			return s[index], true
+				// stop before v overflows near the limit of T, or when a float step is lost by rounding
+				r = v
+				v += by
+				end = (by > 0 && v <= r) || (by < 0 && v >= r)
+				return r, true
+			},
+			hint: func() bounds {
+				switch {
+				case done():
+					return exactSize(0)
+				case v+by == v:
+					return exactSize(1)
+				}
+				return rangeSize(v, stop, by)
+			},
 		},
+		reset: func() { v, end = start, false },
+	}
+}
+
+// rangeSize return number of elements from v to stop by step
+// floats are not exact because of rounding errors accumulated by adding step
+func rangeSize[T constraints.Integer | constraints.Float](v, stop, by T) bounds {
+	n := math.Ceil((float64(stop) - float64(v)) / float64(by))
+	if n >= 1<<53 {
+		return unknownSize
+	}
+
+	half := 0.5
+	if T(half) == 0 {
+		return exactSize(int(n))
+	}
+	return bounds{max(int(n)-1, 0), int(n) + 1, false}
+}
+
+// Reverse reverse elements on the first Next()
+// SliceIterator is read backward without copying, it is consumed on the first Next() like other iterators
+func Reverse[T any](it Iterator[T]) Iterator[T] {
+	if si, ok := it.(SliceIterator[T]); ok {
+		return describe(lazyHint(func() Iterator[T] {
+			rev := newSliceIter(si.Remaining())
+			advance(si, rev.Len())
+			return &withNext[T]{next: rev.Back, hint: func() bounds { return exactSize(rev.Len()) }}
+		}, func() bounds { return exactSize(si.Len()) }), "reverse", it)
 	}
+
+	return describe(lazyHint(func() Iterator[T] {
+		s := slice(it)
+		slices.Reverse(s)
+		return S(s)
+	}, func() bounds { return sizeOf(it) }), "reverse", it)
 }
 
+// Chunk group elements by size, the last chunk may be shorter
+// chunks of SliceIterator share the slice, it is advanced by each chunk like other iterators
 func Chunk[T any](it Iterator[T], size int) Iterator[[]T] {
+	if si, ok := it.(SliceIterator[T]); ok && size > 0 {
+		return describe(chunkSlice(si, size), "chunk", it)
+	}
+
 	last := false
 
 	return &withNext[[]T]{
+		name:    "chunk",
+		parents: []any{it},
 		next: func() ([]T, bool) {
 			chunk := make([]T, 0, size)
 
@@ -68,5 +170,74 @@ func Chunk[T any](it Iterator[T], size int) Iterator[[]T] {
 
 			return chunk, true
 		},
+		close: func() { Close(it) },
+		hint: func() bounds {
+			if last {
+				return exactSize(0)
+			}
+			return sizeOf(it).chunks(size)
+		},
+	}
+}
+
+func chunkSlice[T any](si SliceIterator[T], size int) Iterator[[]T] {
+	return &withNext[[]T]{
+		next: func() ([]T, bool) {
+			if si.Len() == 0 {
+				return nil, false
+			}
+
+			n := min(size, si.Len())
+			chunk := si.Remaining()[:n:n]
+			advance(si, n)
+			return chunk, true
+		},
+		hint: func() bounds { return exactSize(si.Len()).chunks(size) },
+	}
+}
+
+// advance drop n elements of si without reading them
+func advance[T any](si SliceIterator[T], n int) {
+	if s, ok := si.(*sliceIter[T]); ok {
+		s.front = min(s.front+max(n, 0), s.back)
+		return
+	}
+	for ; n > 0; n-- {
+		if _, ok := si.Next(); !ok {
+			return
+		}
 	}
 }
+
+// Last return the last n elements on the first Next()
+// SliceIterator is sliced without copying, others are pulled keeping n elements, both are consumed to the end
+func Last[T any](it Iterator[T], n int) Iterator[T] {
+	if n <= 0 {
+		return describe(lazy(func() Iterator[T] {
+			Close(it)
+			return newSliceIter[T](nil)
+		}), "last", it)
+	}
+
+	if si, ok := it.(SliceIterator[T]); ok {
+		return describe(lazyHint(func() Iterator[T] {
+			rest := si.Remaining()
+			advance(si, len(rest))
+			return newSliceIter(rest[max(len(rest)-n, 0):])
+		}, func() bounds { return exactSize(si.Len()).limit(n) }), "last", it)
+	}
+
+	return describe(lazyHint(func() Iterator[T] {
+		ring := make([]T, 0, n)
+		i := 0
+		for v, ok := it.Next(); ok; v, ok = it.Next() {
+			if len(ring) < n {
+				ring = append(ring, v)
+				continue
+			}
+			ring[i] = v
+			i = (i + 1) % n
+		}
+		return S(append(ring[i:], ring[:i]...))
+	}, func() bounds { return sizeOf(it).limit(n) }), "last", it)
+}
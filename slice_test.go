@@ -1,6 +1,7 @@
 package iter
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -123,3 +124,145 @@ func TestChunk(t *testing.T) {
 		})
 	}
 }
+
+func TestRange(t *testing.T) {
+	tests := [...]struct {
+		name              string
+		start, stop, step int
+		want              []int
+	}{
+		{`valid`, 0, 5, 1, []int{0, 1, 2, 3, 4}},
+		{`step`, 1, 10, 3, []int{1, 4, 7}},
+		{`negative`, 5, 0, -2, []int{5, 3, 1}},
+		{`empty`, 5, 0, 1, nil},
+		{`zero step`, 0, 5, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Range(tt.start, tt.stop, tt.step).Slice())
+		})
+	}
+
+	require.Equal(t, []int{0, 1, 2}, Range(0, 3).Slice())
+	require.Equal(t, []float64{0, 0.25, 0.5, 0.75}, Range(0, 1, 0.25).Slice())
+
+	// stepping past the limit of T must not wrap around
+	require.Equal(t, []uint8{250}, Range[uint8](250, 255, 10).Slice())
+	require.Equal(t, []int{math.MaxInt - 1}, Range(math.MaxInt-1, math.MaxInt, 2).Slice())
+	require.Equal(t, []int{math.MinInt + 1}, Range(math.MinInt+1, math.MinInt, -2).Slice())
+
+	// step below the precision of v does not move it
+	lost := Range(1e16, 1e16+10, 1.0)
+	lo, hi, exact := SizeHint(lost)
+	require.Equal(t, []float64{1e16}, lost.Slice())
+	require.Equal(t, []any{1, 1, true}, []any{lo, hi, exact})
+
+	it := Range[uint8](250, 255, 10)
+	require.Equal(t, []uint8{250}, it.Slice())
+	it.(Reusable[uint8]).Reset()
+	require.Equal(t, []uint8{250}, it.Slice())
+}
+
+func TestSliceIterator(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	it := S(s).(SliceIterator[int])
+
+	require.Equal(t, 5, it.Len())
+	require.Equal(t, 3, it.At(2))
+
+	v, _ := it.Next()
+	require.Equal(t, 1, v)
+	v, _ = it.Back()
+	require.Equal(t, 5, v)
+	require.Equal(t, 3, it.Len())
+	require.Equal(t, 2, it.At(0))
+	require.Equal(t, []int{2, 3, 4}, it.Remaining())
+
+	sub := it.SubSlice(1, 3)
+	require.Equal(t, []int{3, 4}, sub.Slice())
+	require.Equal(t, 3, it.Len(), "SubSlice() does not advance it")
+
+	require.Equal(t, []int{2, 3, 4}, it.Slice())
+	require.Equal(t, 0, it.Len())
+	_, ok := it.Back()
+	require.False(t, ok)
+
+	it.Reset()
+	require.Equal(t, s, it.Remaining())
+
+	rem := it.Remaining()
+	rem = append(rem, 6)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, s, "append to Remaining() must not overwrite the slice")
+	_ = rem
+}
+
+func TestSliceFastPaths(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7}
+
+	type args struct {
+		fn func(Iterator[int]) Iterator[int]
+	}
+	tests := [...]struct {
+		name string
+		args args
+		want []int
+	}{
+		{"reverse", args{Reverse[int]}, []int{7, 6, 5, 4, 3, 2, 1}},
+		{"skip", args{func(it Iterator[int]) Iterator[int] { return it.Skip(5) }}, []int{6, 7}},
+		{"skip all", args{func(it Iterator[int]) Iterator[int] { return it.Skip(10) }}, nil},
+		{"last", args{func(it Iterator[int]) Iterator[int] { return Last(it, 3) }}, []int{5, 6, 7}},
+		{"last more", args{func(it Iterator[int]) Iterator[int] { return Last(it, 10) }}, s},
+		{"last zero", args{func(it Iterator[int]) Iterator[int] { return Last(it, 0) }}, nil},
+		{"chunk", args{func(it Iterator[int]) Iterator[int] { return FlattenSlice(Chunk(it, 3)) }}, s},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fast := S(s)
+			require.Equal(t, tt.want, tt.args.fn(fast).Slice(), "fast path")
+
+			pulled := 0
+			generic := S(s)
+			require.Equal(t, tt.want, tt.args.fn(counter(generic, &pulled)).Slice(), "generic path")
+			require.Equal(t, generic.Slice(), fast.Slice(), "both paths consume the source")
+		})
+	}
+}
+
+func TestSliceFastPathsNoCopy(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+
+	chunks := Chunk(S(s), 2).Slice()
+	require.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, chunks)
+	require.Same(t, &s[2], &chunks[1][0])
+	require.Equal(t, 2, cap(chunks[0]))
+
+	src := S(s)
+	require.Equal(t, []int{4, 5}, Last(src, 2).Slice())
+	require.Empty(t, src.Slice(), "Last() consumes the source")
+
+	it := S(s)
+	require.Equal(t, []int{5, 4, 3, 2, 1}, Reverse(it).Slice())
+	require.Empty(t, it.Slice(), "Reverse() consumes the source")
+}
+
+func TestSliceFastPathsPartial(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+
+	src := S(s)
+	chunk, _ := Chunk(src, 2).Next()
+	require.Equal(t, []int{1, 2}, chunk)
+	require.Equal(t, []int{3, 4, 5}, src.Slice(), "Chunk() advance the source by each chunk")
+
+	src = S(s)
+	v, _ := src.Skip(2).Next()
+	require.Equal(t, 3, v)
+	require.Equal(t, []int{4, 5}, src.Slice())
+}
+
+func TestLast(t *testing.T) {
+	pulled := 0
+	it := Last(counter(Range(0, 10), &pulled), 4)
+	require.Equal(t, 0, pulled)
+	require.Equal(t, []int{6, 7, 8, 9}, it.Slice())
+	require.Equal(t, 10, pulled)
+}
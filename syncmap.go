@@ -0,0 +1,246 @@
+package iter
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// SyncMap wrap sync.Map whose keys are K and values are V
+// entries are copied on the first use, so later changes of m are not seen
+func SyncMap[K comparable, V any](m *sync.Map, opts ...Option) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		r := map[K]V{}
+		m.Range(func(k, v any) bool {
+			r[k.(K)] = v.(V)
+			return true
+		})
+		return r
+	}, opts...), "syncMap")
+}
+
+// Snapshot wrap map guarded by mu, entries are copied under the read lock on the first use
+// use it instead of M() when other goroutines may write m
+func Snapshot[K comparable, V any](m map[K]V, mu *sync.RWMutex, opts ...Option) MapIterator[K, V] {
+	return describeM(lazyM(func() map[K]V {
+		mu.RLock()
+		defer mu.RUnlock()
+
+		r := make(map[K]V, len(m))
+		for k, v := range m {
+			r[k] = v
+		}
+		return r
+	}, opts...), "snapshot")
+}
+
+// ShardedMap is a map safe for concurrent use, keys are spread over shards each with its own lock
+type ShardedMap[K comparable, V any] struct {
+	shards []mapShard[K, V]
+}
+
+type mapShard[K comparable, V any] struct {
+	sync.RWMutex
+	m map[K]V
+}
+
+// NewShardedMap create ShardedMap with n shards, default is runtime.GOMAXPROCS()
+func NewShardedMap[K comparable, V any](n int) *ShardedMap[K, V] {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	shards := make([]mapShard[K, V], n)
+	for i := range shards {
+		shards[i].m = map[K]V{}
+	}
+	return &ShardedMap[K, V]{shards: shards}
+}
+
+func (s *ShardedMap[K, V]) shard(k K) *mapShard[K, V] {
+	return &s.shards[hashKey(k)%uint64(len(s.shards))]
+}
+
+var hashSeed = maphash.MakeSeed()
+
+// hashKey hash k consistently with ==, pointers, channels and interfaces holding them are hashed by identity
+func hashKey[K comparable](k K) uint64 {
+	switch k := any(k).(type) {
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case int32:
+		return mix(uint64(k))
+	case uint:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	case uint32:
+		return mix(uint64(k))
+	case string:
+		return maphash.String(hashSeed, k)
+	}
+
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	hashValue(&h, reflect.ValueOf(&k).Elem())
+	return h.Sum64()
+}
+
+// hashValue write v to h by its kind, v is a comparable value
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // -0 == 0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name != "_" { // blank fields are ignored by ==
+				hashValue(h, v.Field(i))
+			}
+		}
+	default:
+		panic(fmt.Sprintf("iter: unhashable key kind %s", v.Kind()))
+	}
+}
+
+// mix spread bits of hash so similar keys don't fall into the same shards
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	return x
+}
+
+func (s *ShardedMap[K, V]) Store(k K, v V) {
+	sh := s.shard(k)
+	sh.Lock()
+	sh.m[k] = v
+	sh.Unlock()
+}
+
+func (s *ShardedMap[K, V]) Load(k K) (v V, ok bool) {
+	sh := s.shard(k)
+	sh.RLock()
+	v, ok = sh.m[k]
+	sh.RUnlock()
+	return v, ok
+}
+
+// Update set value of k to fn(old value, exists) atomically
+func (s *ShardedMap[K, V]) Update(k K, fn func(V, bool) V) {
+	sh := s.shard(k)
+	sh.Lock()
+	v, ok := sh.m[k]
+	sh.m[k] = fn(v, ok)
+	sh.Unlock()
+}
+
+func (s *ShardedMap[K, V]) Delete(k K) {
+	sh := s.shard(k)
+	sh.Lock()
+	delete(sh.m, k)
+	sh.Unlock()
+}
+
+func (s *ShardedMap[K, V]) Len() (n int) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		n += len(sh.m)
+		sh.RUnlock()
+	}
+	return n
+}
+
+// ToMap return copy of entries, each shard is copied under its own read lock
+func (s *ShardedMap[K, V]) ToMap() map[K]V {
+	r := map[K]V{}
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		for k, v := range sh.m {
+			r[k] = v
+		}
+		sh.RUnlock()
+	}
+	return r
+}
+
+// M return MapIterator of snapshot taken on the first use
+func (s *ShardedMap[K, V]) M(opts ...Option) MapIterator[K, V] {
+	return describeM(lazyM(s.ToMap, opts...), "shardedMap")
+}
+
+// ToShardedMap store items into a new ShardedMap with shards shards
+// with WithConcurrency() items are stored by that many goroutines
+func ToShardedMap[K comparable, V any](it Iterator[Item[K, V]], shards int, opts ...Option) *ShardedMap[K, V] {
+	s := NewShardedMap[K, V](shards)
+
+	n := newOptions(opts...).concurrency
+	if n <= 1 {
+		for item, ok := it.Next(); ok; item, ok = it.Next() {
+			s.Store(item.Key, item.Value)
+		}
+		return s
+	}
+
+	ch := pump(context.Background(), it)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range ch {
+				s.Store(item.Key, item.Value)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return s
+}
@@ -0,0 +1,148 @@
+package iter
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncMap(t *testing.T) {
+	var m sync.Map
+	m.Store(1, "one")
+	m.Store(2, "two")
+
+	it := SyncMap[int, string](&m)
+	require.Equal(t, []int{1, 2}, Sorted(it.Keys()).Slice())
+	require.Equal(t, []string{"one", "two"}, Sorted(it.Values()).Slice())
+	require.Equal(t, map[int]string{1: "one", 2: "two"}, it.ToMap())
+}
+
+func TestSnapshot(t *testing.T) {
+	var mu sync.RWMutex
+	m := map[int]int{}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			mu.Lock()
+			m[i] = i
+			mu.Unlock()
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		got := Snapshot(m, &mu).Items().Slice()
+		for _, item := range got {
+			require.Equal(t, item.Key, item.Value)
+		}
+	}
+	wg.Wait()
+
+	require.Len(t, Snapshot(m, &mu).Keys().Slice(), 1000)
+}
+
+func TestShardedMap(t *testing.T) {
+	s := NewShardedMap[string, int](4)
+	s.Store("a", 1)
+	s.Store("b", 2)
+	s.Update("a", func(v int, ok bool) int { require.True(t, ok); return v + 10 })
+	s.Update("c", func(v int, ok bool) int { require.False(t, ok); return 3 })
+	s.Delete("b")
+
+	v, ok := s.Load("a")
+	require.True(t, ok)
+	require.Equal(t, 11, v)
+	_, ok = s.Load("b")
+	require.False(t, ok)
+
+	require.Equal(t, 2, s.Len())
+	require.Equal(t, map[string]int{"a": 11, "c": 3}, s.ToMap())
+	require.Equal(t, []string{"a", "c"}, Sorted(s.M().Keys()).Slice())
+}
+
+func TestShardedMapSpread(t *testing.T) {
+	type key struct{ a, b int }
+
+	s := NewShardedMap[key, int](8)
+	for i := 0; i < 1000; i++ {
+		s.Store(key{i, -i}, i)
+	}
+	require.Equal(t, 1000, s.Len())
+	for i := range s.shards {
+		require.NotEmpty(t, s.shards[i].m, "shard %d", i)
+	}
+}
+
+func TestShardedMapKeyIdentity(t *testing.T) {
+	type node struct{ n int }
+	type ref struct {
+		p *node
+		_ int
+	}
+
+	k := &node{1}
+	s := NewShardedMap[*node, int](8)
+	s.Store(k, 1)
+	k.n = 12345
+	v, ok := s.Load(k)
+	require.True(t, ok, "pointer key is hashed by identity")
+	require.Equal(t, 1, v)
+	_, ok = s.Load(&node{12345})
+	require.False(t, ok)
+
+	// equal keys have equal hashes
+	require.Equal(t, hashKey(ref{p: k}), hashKey(ref{p: k}))
+	require.Equal(t, hashKey[any](k), hashKey[any](k))
+	require.Equal(t, hashKey[any](1), hashKey(1))
+	require.Equal(t, hashKey[any](nil), hashKey[any](nil))
+	require.Equal(t, hashKey(0.0), hashKey(math.Copysign(0, -1)))
+}
+
+func TestToShardedMap(t *testing.T) {
+	type args struct {
+		opts []Option
+	}
+	tests := [...]struct {
+		name string
+		args args
+	}{
+		{"sequential", args{nil}},
+		{"concurrent", args{[]Option{WithConcurrency(4)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := Map(Range(0, 1000), func(x int) Item[string, int] { return Item[string, int]{strconv.Itoa(x), x} })
+			s := ToShardedMap(items, 0, tt.args.opts...)
+
+			require.Equal(t, 1000, s.Len())
+			v, ok := s.Load("999")
+			require.True(t, ok)
+			require.Equal(t, 999, v)
+		})
+	}
+}
+
+func TestShardedMapConcurrentWriters(t *testing.T) {
+	s := NewShardedMap[int, int](0)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Update(i, func(v int, _ bool) int { return v + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k, v := range s.ToMap() {
+		require.Equal(t, 8, v, "key %d", k)
+	}
+}